@@ -0,0 +1,175 @@
+// Package cache provides a pluggable response cache for the Astrology API SDK.
+//
+// Enable caching with option.WithCache. Only requests with a positive TTL are
+// cached: set a client-wide TTL with option.WithCacheTTL, or rely on the
+// defaults of reference-data endpoints such as the glossary.
+//
+//	client := astroapi.NewClient(
+//	    option.WithAPIKey("your-api-key"),
+//	    option.WithCache(cache.NewLRU(1000)),
+//	    option.WithCacheTTL(10*time.Minute),
+//	)
+package cache
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Cache stores raw successful response bodies keyed by request.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the cached value for key, or false if it is missing or expired.
+	Get(key string) ([]byte, bool)
+	// Set stores value under key for the given ttl.
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// Key returns the cache key for a request. It covers the method, the full URL
+// (including the query string), the request headers and a hash of the
+// canonical JSON body, so that bodies differing only in key order or
+// whitespace share an entry. Headers such as Authorization and
+// Accept-Language change the response, so requests made with different keys
+// or languages never share an entry.
+func Key(method, url string, header http.Header, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(url))
+	h.Write([]byte{0})
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		h.Write([]byte(name))
+		for _, v := range header[name] {
+			h.Write([]byte{0})
+			h.Write([]byte(v))
+		}
+		h.Write([]byte{0})
+	}
+	h.Write([]byte{0})
+	h.Write(canonicalJSON(body))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// canonicalJSON re-encodes body with sorted object keys and no insignificant
+// whitespace. Bodies that are not valid JSON are returned unchanged.
+func canonicalJSON(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return body
+	}
+	canonical, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return canonical
+}
+
+// LRU is an in-memory Cache that evicts the least recently used entry once
+// it holds more than its capacity. It is safe for concurrent use.
+type LRU struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+	now      func() time.Time
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewLRU creates an LRU cache holding at most capacity entries.
+// A capacity below 1 is treated as 1.
+func NewLRU(capacity int) *LRU {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LRU{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+// Get implements Cache.
+func (c *LRU) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*lruEntry)
+	if !c.now().Before(entry.expires) {
+		c.remove(el)
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return entry.value, true
+}
+
+// Set implements Cache. Entries with a non-positive ttl are not stored.
+func (c *LRU) Set(key string, value []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.now().Add(ttl)
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*lruEntry)
+		entry.value = value
+		entry.expires = expires
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	for c.ll.Len() > c.capacity {
+		c.remove(c.ll.Back())
+	}
+}
+
+// Delete removes key from the cache.
+func (c *LRU) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+}
+
+// Len returns the number of entries currently held, including expired
+// entries that have not been evicted yet.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+func (c *LRU) remove(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*lruEntry).key)
+}
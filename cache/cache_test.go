@@ -0,0 +1,87 @@
+package cache_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/astro-api/astroapi-go/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU_GetSet(t *testing.T) {
+	c := cache.NewLRU(2)
+	c.Set("a", []byte("1"), time.Minute)
+
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), v)
+
+	_, ok = c.Get("missing")
+	assert.False(t, ok)
+}
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := cache.NewLRU(2)
+	c.Set("a", []byte("1"), time.Minute)
+	c.Set("b", []byte("2"), time.Minute)
+	_, _ = c.Get("a") // a is now most recently used
+	c.Set("c", []byte("3"), time.Minute)
+
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	_, ok = c.Get("a")
+	assert.True(t, ok)
+	_, ok = c.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestLRU_Expiry(t *testing.T) {
+	c := cache.NewLRU(2)
+	c.Set("a", []byte("1"), 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestLRU_NonPositiveTTLNotStored(t *testing.T) {
+	c := cache.NewLRU(2)
+	c.Set("a", []byte("1"), 0)
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+}
+
+func TestLRU_Delete(t *testing.T) {
+	c := cache.NewLRU(2)
+	c.Set("a", []byte("1"), time.Minute)
+	c.Delete("a")
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+}
+
+func TestKey_CanonicalBody(t *testing.T) {
+	a := cache.Key("POST", "https://example.com/x", nil, []byte(`{"b":1,"a":{"y":2,"x":1}}`))
+	b := cache.Key("POST", "https://example.com/x", nil, []byte(`{ "a": {"x":1,"y":2}, "b": 1 }`))
+	assert.Equal(t, a, b)
+
+	assert.NotEqual(t, a, cache.Key("GET", "https://example.com/x", nil, []byte(`{"b":1,"a":{"y":2,"x":1}}`)))
+	assert.NotEqual(t, a, cache.Key("POST", "https://example.com/y", nil, []byte(`{"b":1,"a":{"y":2,"x":1}}`)))
+	assert.NotEqual(t, a, cache.Key("POST", "https://example.com/x", nil, []byte(`{"b":2,"a":{"y":2,"x":1}}`)))
+}
+
+func TestKey_Headers(t *testing.T) {
+	h := http.Header{"Authorization": {"Bearer a"}, "Accept-Language": {"en"}}
+	a := cache.Key("GET", "https://example.com/x", h, nil)
+	assert.Equal(t, a, cache.Key("GET", "https://example.com/x", h.Clone(), nil))
+
+	other := h.Clone()
+	other.Set("Authorization", "Bearer b")
+	assert.NotEqual(t, a, cache.Key("GET", "https://example.com/x", other, nil))
+
+	other = h.Clone()
+	other.Set("Accept-Language", "de")
+	assert.NotEqual(t, a, cache.Key("GET", "https://example.com/x", other, nil))
+}
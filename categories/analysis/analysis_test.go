@@ -9,6 +9,7 @@ import (
 	"github.com/astro-api/astroapi-go/categories/analysis"
 	"github.com/astro-api/astroapi-go/internal/testutil"
 	"github.com/astro-api/astroapi-go/option"
+	"github.com/astro-api/astroapi-go/shared"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -191,7 +192,7 @@ func TestAnalysisClient_GetProgressionReport(t *testing.T) {
 
 	result, err := client.Analysis.GetProgressionReport(ctx, analysis.ProgressionReportParams{
 		Subject:         testutil.DefaultSubject(),
-		TargetDate:      "2024-05-11",
+		TargetDate:      shared.NewDate(2024, 5, 11),
 		ProgressionType: "secondary",
 	})
 	require.NoError(t, err)
@@ -207,7 +208,7 @@ func TestAnalysisClient_GetDirectionReport(t *testing.T) {
 
 	result, err := client.Analysis.GetDirectionReport(ctx, analysis.DirectionReportParams{
 		Subject:       testutil.DefaultSubject(),
-		TargetDate:    "2024-05-11",
+		TargetDate:    shared.NewDate(2024, 5, 11),
 		DirectionType: "solar_arc",
 	})
 	require.NoError(t, err)
@@ -223,7 +224,7 @@ func TestAnalysisClient_GetLunarReturnReport(t *testing.T) {
 
 	result, err := client.Analysis.GetLunarReturnReport(ctx, analysis.LunarReturnReportParams{
 		Subject:    testutil.DefaultSubject(),
-		ReturnDate: "2024-05-11",
+		ReturnDate: shared.NewDate(2024, 5, 11),
 	})
 	require.NoError(t, err)
 	assert.NotNil(t, result)
@@ -14,37 +14,37 @@ const apiPrefix = "api/v3/astrocartography"
 type GenericResponse map[string]any
 
 type LinesParams struct {
-	Subject shared.Subject `json:"subject" validate:"required"`
+	Subject shared.Subject `json:"subject" validate:"required,place"`
 	Options *shared.AstrologyOptions `json:"options,omitempty"`
 }
 
 type LocationAnalysisParams struct {
-	Subject  shared.Subject          `json:"subject" validate:"required"`
+	Subject  shared.Subject          `json:"subject" validate:"required,place"`
 	Location shared.DateTimeLocation `json:"location" validate:"required"`
 	Options  *shared.AstrologyOptions `json:"options,omitempty"`
 }
 
 type CompareLocationsParams struct {
-	Subject   shared.Subject            `json:"subject" validate:"required"`
+	Subject   shared.Subject            `json:"subject" validate:"required,place"`
 	Locations []shared.DateTimeLocation `json:"locations" validate:"required"`
 }
 
 type PowerZonesParams struct {
-	Subject shared.Subject `json:"subject" validate:"required"`
+	Subject shared.Subject `json:"subject" validate:"required,place"`
 }
 
 type RelocationChartParams struct {
-	Subject  shared.Subject          `json:"subject" validate:"required"`
+	Subject  shared.Subject          `json:"subject" validate:"required,place"`
 	Location shared.DateTimeLocation `json:"location" validate:"required"`
 }
 
 type SearchLocationsParams struct {
-	Subject  shared.Subject `json:"subject" validate:"required"`
+	Subject  shared.Subject `json:"subject" validate:"required,place"`
 	Criteria map[string]any `json:"criteria,omitempty"`
 }
 
 type MapParams struct {
-	Subject shared.Subject `json:"subject" validate:"required"`
+	Subject shared.Subject `json:"subject" validate:"required,place"`
 	Options map[string]any `json:"options,omitempty"`
 }
 
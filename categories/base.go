@@ -2,27 +2,92 @@
 package categories
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 	"reflect"
+	"regexp"
+	"runtime"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/astro-api/astroapi-go/cache"
+	"github.com/astro-api/astroapi-go/credentials"
 	astroerrors "github.com/astro-api/astroapi-go/errors"
+	"github.com/astro-api/astroapi-go/internal/form"
+	"github.com/astro-api/astroapi-go/internal/headers"
 	"github.com/astro-api/astroapi-go/internal/requestconfig"
 	"github.com/astro-api/astroapi-go/internal/transport"
 	"github.com/astro-api/astroapi-go/internal/validator"
 	"github.com/astro-api/astroapi-go/option"
+	"github.com/astro-api/astroapi-go/shared"
 )
 
 // BaseCategoryClient is the shared HTTP foundation for all category clients.
+// Create it with NewBaseCategoryClient so the transport stack is built once and
+// connections are pooled across requests.
 type BaseCategoryClient struct {
 	Config *requestconfig.RequestConfig
+
+	once       sync.Once
+	transport  http.RoundTripper
+	httpClient *http.Client
+}
+
+// NewBaseCategoryClient creates a BaseCategoryClient and builds its HTTP client
+// and transport stack from cfg.
+func NewBaseCategoryClient(cfg *requestconfig.RequestConfig) *BaseCategoryClient {
+	b := &BaseCategoryClient{Config: cfg}
+	b.init()
+	return b
+}
+
+// init builds the shared transport stack. It is safe to call more than once.
+func (b *BaseCategoryClient) init() {
+	b.once.Do(func() {
+		b.transport = baseTransport(b.Config)
+		b.httpClient = buildHTTPClient(b.Config, b.transport)
+	})
+}
+
+// Derive returns a BaseCategoryClient for cfg that shares b's pooled base
+// transport. Pointers held by cfg, such as the cache and rate limiter, are
+// shared as well.
+func (b *BaseCategoryClient) Derive(cfg *requestconfig.RequestConfig) *BaseCategoryClient {
+	b.init()
+	d := &BaseCategoryClient{Config: cfg, transport: b.transport}
+	if cfg.HTTPClient != b.Config.HTTPClient {
+		d.transport = baseTransport(cfg)
+	}
+	d.once.Do(func() {
+		d.httpClient = buildHTTPClient(cfg, d.transport)
+	})
+	return d
+}
+
+// client returns the http.Client to use for a request with the given config.
+// The shared client is reused unless per-request options changed a transport
+// setting, in which case a new stack is built on top of the same pooled
+// base transport.
+func (b *BaseCategoryClient) client(cfg *requestconfig.RequestConfig) *http.Client {
+	b.init()
+	if sameTransportSettings(b.Config, cfg) {
+		return b.httpClient
+	}
+	base := b.transport
+	if cfg.HTTPClient != b.Config.HTTPClient {
+		base = baseTransport(cfg)
+	}
+	return buildHTTPClient(cfg, base)
 }
 
 // BuildURL joins the Config.BaseURL with the provided path segments.
@@ -62,13 +127,15 @@ func (b *BaseCategoryClient) Delete(ctx context.Context, rawURL string, params a
 	return b.MakeRequest(ctx, http.MethodDelete, rawURL, params, out, opts...)
 }
 
+// WithDefaultCacheTTL prepends a cache TTL to opts. Category clients use it to
+// give reference-data endpoints their own TTL while still letting callers
+// override it with option.WithCacheTTL.
+func WithDefaultCacheTTL(ttl time.Duration, opts []option.RequestOption) []option.RequestOption {
+	return append([]option.RequestOption{option.WithCacheTTL(ttl)}, opts...)
+}
+
 // MakeRequest is the core request method used by all category clients.
 func (b *BaseCategoryClient) MakeRequest(ctx context.Context, method, rawURL string, params any, out any, opts ...option.RequestOption) error {
-	// Validate params.
-	if err := validator.Validate(params); err != nil {
-		return fmt.Errorf("validation error: %w", err)
-	}
-
 	// Clone and apply per-request options.
 	cfg := b.Config.Clone()
 	rawOpts := make([]func(*requestconfig.RequestConfig), len(opts))
@@ -77,13 +144,61 @@ func (b *BaseCategoryClient) MakeRequest(ctx context.Context, method, rawURL str
 	}
 	cfg.Apply(rawOpts)
 
+	if cfg.Logger != nil {
+		enableLogging(ctx, cfg)
+	}
+	var endpoint string
+	if cfg.Hooks != nil || cfg.Breaker != nil {
+		endpoint = endpointName()
+	}
+	if cfg.Breaker != nil && endpoint != "" {
+		ctx = transport.WithEndpoint(ctx, endpoint)
+	}
+	if cfg.Hooks != nil {
+		return b.makeObservedRequest(ctx, cfg, endpoint, method, rawURL, params, out)
+	}
+	return b.makeRequest(ctx, cfg, &transport.Trace{}, &outcome{}, method, rawURL, params, out)
+}
+
+// outcome is the final response of a call, as reported to hooks.
+type outcome struct {
+	statusCode int
+	header     http.Header
+	cached     bool
+}
+
+// makeRequest sends one call with the per-request config cfg. RetryTransport
+// records each attempt into trace, and the final response is recorded into
+// res.
+func (b *BaseCategoryClient) makeRequest(ctx context.Context, cfg *requestconfig.RequestConfig, trace *transport.Trace, res *outcome, method, rawURL string, params any, out any) error {
+	// Validate params. The *astroerrors.ValidationError lists every violation.
+	if err := validator.Validate(params); err != nil {
+		return err
+	}
+	if cfg.Err != nil {
+		return cfg.Err
+	}
+	params = withDefaultOptions(params, cfg)
+
+	// The request timeout bounds the whole call: rate limiting, every retry
+	// attempt with its backoff, and reading the response.
+	timeout := cfg.RequestTimeout
+	if timeout <= 0 {
+		timeout = requestconfig.DefaultRequestTimeout
+	}
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout, astroerrors.ErrRequestTimeout)
+	defer cancel()
+
 	// Build the HTTP request.
-	var bodyReader io.Reader
+	var (
+		body       []byte
+		bodyReader io.Reader
+	)
 	finalURL := rawURL
 
 	if method == http.MethodGet {
 		if params != nil {
-			qp, err := encodeQueryParams(params)
+			qp, err := form.Encode(params, cfg.QueryNesting)
 			if err != nil {
 				return fmt.Errorf("encoding query params: %w", err)
 			}
@@ -93,15 +208,18 @@ func (b *BaseCategoryClient) MakeRequest(ctx context.Context, method, rawURL str
 		}
 	} else {
 		if params != nil {
-			data, err := json.Marshal(params)
+			var err error
+			body, err = json.Marshal(params)
 			if err != nil {
 				return fmt.Errorf("marshalling request body: %w", err)
 			}
-			bodyReader = bytes.NewReader(data)
+			bodyReader = bytes.NewReader(body)
 		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, finalURL, bodyReader)
+	started := time.Now()
+
+	req, err := http.NewRequestWithContext(transport.WithTrace(ctx, trace), method, finalURL, bodyReader)
 	if err != nil {
 		return fmt.Errorf("creating request: %w", err)
 	}
@@ -113,22 +231,89 @@ func (b *BaseCategoryClient) MakeRequest(ctx context.Context, method, rawURL str
 		}
 	}
 
-	// Build and execute with auth + retry transports.
-	httpClient := buildHTTPClient(cfg)
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("executing request: %w", err)
+	// Tag non-GET calls with an idempotency key. Every retry attempt clones
+	// this request, so the key stays the same for the whole logical call.
+	if method != http.MethodGet && req.Header.Get(transport.IdempotencyKeyHeader) == "" {
+		key := cfg.IdempotencyKey
+		if key == "" {
+			if key, err = newIdempotencyKey(); err != nil {
+				return fmt.Errorf("generating idempotency key: %w", err)
+			}
+		}
+		req.Header.Set(transport.IdempotencyKeyHeader, key)
 	}
-	defer resp.Body.Close()
 
-	// Store raw response if requested.
-	if cfg.ResponseInto != nil {
-		*cfg.ResponseInto = resp
+	// Serve from the response cache when it is enabled for this request.
+	// The lookup runs innermost in the middleware chain, so the key covers
+	// headers that middlewares add.
+	var (
+		cacheKey string
+		cached   []byte
+	)
+	do := b.client(cfg).Do
+	if cfg.Cache != nil && cfg.CacheTTL > 0 && out != nil {
+		do = func(r *http.Request) (*http.Response, error) {
+			if cacheKey = cacheKeyFor(ctx, cfg, r, body); cacheKey != "" {
+				if data, ok := cfg.Cache.Get(cacheKey); ok {
+					cached = data
+					return &http.Response{
+						StatusCode:    http.StatusOK,
+						Header:        make(http.Header),
+						Body:          io.NopCloser(bytes.NewReader(data)),
+						ContentLength: int64(len(data)),
+						Request:       r,
+					}, nil
+				}
+			}
+			return b.client(cfg).Do(r)
+		}
 	}
 
+	// Execute with the auth + retry transport stack, wrapped in middlewares.
+	resp, err := send(do, cfg.Middlewares, req)
+	if err != nil {
+		if cfg.ResponseMeta != nil {
+			recordResponseMeta(cfg.ResponseMeta, nil, trace, started, nil)
+		}
+		return fmt.Errorf("executing request: %w", timeoutCause(ctx, timeout, err))
+	}
+	if cached != nil {
+		resp.Body.Close()
+		*res = outcome{statusCode: resp.StatusCode, header: resp.Header, cached: true}
+		if cfg.ResponseMeta != nil {
+			recordResponseMeta(cfg.ResponseMeta, resp, trace, started, cached)
+			cfg.ResponseMeta.Cached = true
+		}
+		return unsuccessfulToAPIError(req, resp, decodeBody(cached, out, decodeOptionsFor(cfg)))
+	}
+	defer resp.Body.Close()
+	*res = outcome{statusCode: resp.StatusCode, header: resp.Header}
+
 	// Handle non-2xx responses.
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return astroerrors.NewFromResponse(req, resp)
+		apiErr := astroerrors.NewFromResponse(req, resp)
+		keepResponse(cfg, resp, trace, started, []byte(apiErr.Body))
+		return apiErr
+	}
+
+	// Buffer the body when it has to outlive this call: for the cache, for
+	// ResponseMeta or for ResponseInto.
+	if cacheKey != "" || cfg.ResponseMeta != nil || cfg.ResponseInto != nil {
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("reading response body: %w", timeoutCause(ctx, timeout, err))
+		}
+		keepResponse(cfg, resp, trace, started, data)
+		if out == nil {
+			return nil
+		}
+		if err := decodeBody(data, out, decodeOptionsFor(cfg)); err != nil {
+			return unsuccessfulToAPIError(req, resp, err)
+		}
+		if cacheKey != "" && !reportsFailure(data) {
+			cfg.Cache.Set(cacheKey, data, cfg.CacheTTL)
+		}
+		return nil
 	}
 
 	// If out is nil, discard the body.
@@ -141,112 +326,700 @@ func (b *BaseCategoryClient) MakeRequest(ctx context.Context, method, rawURL str
 	if sp, ok := out.(*string); ok {
 		b2, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return fmt.Errorf("reading response body: %w", err)
+			return fmt.Errorf("reading response body: %w", timeoutCause(ctx, timeout, err))
 		}
 		*sp = string(b2)
 		return nil
 	}
 
-	return unmarshalResponse(resp.Body, out)
+	return unsuccessfulToAPIError(req, resp, unmarshalResponse(resp.Body, out, decodeOptionsFor(cfg)))
+}
+
+// cacheKeyFor returns the cache key of req. It covers the API key the
+// request will be sent with and its headers, including those set by
+// middlewares, so that clients derived with another key or language never
+// share entries. It is empty, disabling the
+// cache for the call, if the key cannot be resolved.
+func cacheKeyFor(ctx context.Context, cfg *requestconfig.RequestConfig, req *http.Request, body []byte) string {
+	header := req.Header.Clone()
+	header.Del(transport.IdempotencyKeyHeader)
+	if header.Get("Authorization") == "" {
+		key := cfg.APIKey
+		if cfg.Credentials != nil {
+			var err error
+			if key, err = cfg.Credentials.APIKey(ctx); err != nil {
+				return ""
+			}
+		}
+		header.Set("Authorization", "Bearer "+key)
+	}
+	return cache.Key(req.Method, req.URL.String(), header, body)
+}
+
+// send runs req through middlewares, the last of which hands it to client.
+func send(do requestconfig.MiddlewareNext, middlewares []requestconfig.Middleware, req *http.Request) (*http.Response, error) {
+	next := do
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		mw, inner := middlewares[i], next
+		next = func(r *http.Request) (*http.Response, error) { return mw(r, inner) }
+	}
+	resp, err := next(req)
+	if err == nil && resp == nil {
+		return nil, fmt.Errorf("middleware returned neither a response nor an error")
+	}
+	return resp, err
+}
+
+// timeoutCause names the request timeout in err if it is what ended ctx.
+func timeoutCause(ctx context.Context, timeout time.Duration, err error) error {
+	if errors.Is(context.Cause(ctx), astroerrors.ErrRequestTimeout) && !errors.Is(err, astroerrors.ErrRequestTimeout) {
+		return fmt.Errorf("%w after %s: %w", astroerrors.ErrRequestTimeout, timeout, err)
+	}
+	return err
+}
+
+// makeObservedRequest runs makeRequest and reports its progress to cfg.Hooks.
+func (b *BaseCategoryClient) makeObservedRequest(ctx context.Context, cfg *requestconfig.RequestConfig, endpoint, method, rawURL string, params any, out any) error {
+	h := cfg.Hooks
+	ev := requestconfig.RequestEvent{Context: ctx, Endpoint: endpoint, Method: method, URL: rawURL}
+	if h.OnRequestStart != nil {
+		h.OnRequestStart(ev)
+	}
+
+	trace := &transport.Trace{}
+	if h.OnAttempt != nil {
+		trace.OnAttempt = func(n int, a transport.Attempt) {
+			h.OnAttempt(requestconfig.AttemptEvent{
+				RequestEvent: ev,
+				Attempt:      n,
+				Delay:        a.Delay,
+				StatusCode:   a.StatusCode,
+				Err:          a.Err,
+				Latency:      a.Latency,
+			})
+		}
+	}
+	var res outcome
+	started := time.Now()
+	err := b.makeRequest(ctx, cfg, trace, &res, method, rawURL, params, out)
+	latency := time.Since(started)
+	switch {
+	case err != nil:
+		if h.OnError != nil {
+			h.OnError(requestconfig.ErrorEvent{
+				RequestEvent: ev,
+				Err:          err,
+				StatusCode:   res.statusCode,
+				Attempts:     len(trace.Attempts),
+				Latency:      latency,
+			})
+		}
+	case res.cached:
+		if h.OnCacheHit != nil {
+			h.OnCacheHit(ev)
+		}
+	default:
+		if h.OnResponse != nil {
+			h.OnResponse(requestconfig.ResponseEvent{
+				RequestEvent: ev,
+				StatusCode:   res.statusCode,
+				Header:       res.header,
+				Attempts:     len(trace.Attempts),
+				Latency:      latency,
+			})
+		}
+	}
+	return err
+}
+
+// categoriesPrefix is the import path of this package followed by a dot, so
+// that it matches its functions but not those of the category packages.
+const categoriesPrefix = "github.com/astro-api/astroapi-go/categories."
+
+// endpointName returns the name of the category method that called into this
+// package, e.g. "charts.GetNatal", or "insights.relationship.GetCompatibility"
+// for the methods of insights.RelationshipClient. It is empty if the caller
+// is not a category method.
+func endpointName() string {
+	var pcs [16]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, categoriesPrefix) {
+			return formatEndpoint(frame.Function)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// formatEndpoint turns a function name such as
+// "github.com/astro-api/astroapi-go/categories/charts.(*Client).GetNatal"
+// into "charts.GetNatal".
+func formatEndpoint(function string) string {
+	rest, ok := strings.CutPrefix(function, strings.TrimSuffix(categoriesPrefix, ".")+"/")
+	if !ok {
+		return ""
+	}
+	pkg, rest, _ := strings.Cut(rest, ".")
+	recv, method, ok := strings.Cut(rest, ".")
+	if !ok {
+		return ""
+	}
+	recv = strings.TrimSuffix(strings.Trim(recv, "(*)"), "Client")
+	if recv == "" {
+		return pkg + "." + method
+	}
+	return pkg + "." + strings.ToLower(recv[:1]) + recv[1:] + "." + method
+}
+
+// newIdempotencyKey returns a random (version 4) UUID.
+func newIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// keepResponse hands the response to ResponseInto and ResponseMeta, if
+// requested. The already read body is replaced with an in-memory copy.
+func keepResponse(cfg *requestconfig.RequestConfig, resp *http.Response, trace *transport.Trace, started time.Time, body []byte) {
+	if cfg.ResponseMeta != nil {
+		recordResponseMeta(cfg.ResponseMeta, resp, trace, started, body)
+	}
+	if cfg.ResponseInto != nil {
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		*cfg.ResponseInto = resp
+	}
+}
+
+// recordResponseMeta fills meta from the final response (nil on network
+// errors) and the attempts recorded in trace.
+func recordResponseMeta(meta *requestconfig.ResponseMeta, resp *http.Response, trace *transport.Trace, started time.Time, body []byte) {
+	*meta = requestconfig.ResponseMeta{
+		RateLimitRemaining: -1,
+		Attempts:           len(trace.Attempts),
+		Latency:            time.Since(started),
+		Body:               body,
+	}
+	for _, a := range trace.Attempts {
+		meta.AttemptLatencies = append(meta.AttemptLatencies, a.Latency)
+	}
+	if resp == nil {
+		return
+	}
+	meta.StatusCode = resp.StatusCode
+	meta.Header = resp.Header
+	meta.RequestID = resp.Header.Get("X-Request-Id")
+	if remaining, ok := headers.RateLimitRemaining(resp.Header); ok {
+		meta.RateLimitRemaining = remaining
+	}
+	if reset, ok := headers.RateLimitReset(resp.Header, time.Now()); ok {
+		meta.RateLimitReset = reset
+	}
+}
+
+// unsuccessfulToAPIError converts an *unsuccessfulError into an
+// *errors.AstrologyError for the response. Other errors are returned as is.
+func unsuccessfulToAPIError(req *http.Request, resp *http.Response, err error) error {
+	var unsuccessful *unsuccessfulError
+	if errors.As(err, &unsuccessful) {
+		return astroerrors.NewFromBody(req, resp, unsuccessful.body)
+	}
+	return err
+}
+
+// decodeOptions controls how successful response bodies are decoded.
+type decodeOptions struct {
+	strict          bool
+	disallowUnknown bool
+}
+
+func decodeOptionsFor(cfg *requestconfig.RequestConfig) decodeOptions {
+	return decodeOptions{strict: cfg.StrictDecoding, disallowUnknown: cfg.DisallowUnknownFields}
+}
+
+func (o decodeOptions) newDecoder(r io.Reader) *json.Decoder {
+	dec := json.NewDecoder(r)
+	if o.disallowUnknown {
+		dec.DisallowUnknownFields()
+	}
+	return dec
+}
+
+// unsuccessfulError is returned by strict decoding for a 2xx body reporting
+// "success": false. MakeRequest turns it into an *errors.AstrologyError.
+type unsuccessfulError struct {
+	body []byte
 }
 
-// unmarshalResponse reads the response body and unmarshals it into out.
-// It tries the {"data":...} and {"result":...} envelope formats first,
-// then falls back to direct unmarshalling.
-func unmarshalResponse(body io.Reader, out any) error {
-	data, err := io.ReadAll(body)
+func (e *unsuccessfulError) Error() string { return "response reported success: false" }
+
+// reportsFailure reports whether body is a JSON object with "success":
+// false. Lenient decoding accepts such bodies, but they are not cached.
+func reportsFailure(body []byte) bool {
+	var env struct {
+		Success *bool `json:"success"`
+	}
+	return json.Unmarshal(body, &env) == nil && env.Success != nil && !*env.Success
+}
+
+// decodeBody decodes an already buffered response body into out.
+func decodeBody(data []byte, out any, opts decodeOptions) error {
+	if sp, ok := out.(*string); ok {
+		*sp = string(data)
+		return nil
+	}
+	return unmarshalResponse(bytes.NewReader(data), out, opts)
+}
+
+// unmarshalResponse decodes the response body into out in a single pass.
+// It walks the top-level JSON object token by token and decodes the first
+// "data" or "result" member whose JSON kind fits out straight into out,
+// instead of unmarshalling the body twice. It does not save memory:
+// json.Decoder buffers each value it decodes, so the envelope value is held
+// in memory as before, and the time saved is small. Bodies without a usable envelope are decoded as a
+// whole. The rest of the body is always drained, so that the connection
+// can be reused.
+func unmarshalResponse(body io.Reader, out any, opts decodeOptions) error {
+	br := bufio.NewReader(body)
+	defer func() { _, _ = io.Copy(io.Discard, br) }()
+	if first, err := peekNonSpace(br); err != nil || first != '{' {
+		if err := opts.newDecoder(br).Decode(out); err != nil {
+			if opts.strict {
+				return newDecodeError("", err)
+			}
+			return fmt.Errorf("unmarshalling response: %w", err)
+		}
+		return nil
+	}
+
+	dec := opts.newDecoder(br)
+	if opts.strict {
+		return decodeStrict(dec, out, opts)
+	}
+	found, members, err := decodeEnvelope(dec, out)
 	if err != nil {
-		return fmt.Errorf("reading response body: %w", err)
+		return fmt.Errorf("unmarshalling response: %w", err)
+	}
+	if found {
+		return nil
 	}
+	return unmarshalDirect(joinMembers(members), out)
+}
 
-	// Try envelope unwrapping.
-	var env map[string]json.RawMessage
-	if jsonErr := json.Unmarshal(data, &env); jsonErr == nil {
-		for _, key := range []string{"data", "result"} {
-			if raw, ok := env[key]; ok {
-				if err := json.Unmarshal(raw, out); err == nil {
-					return nil
+// decodeStrict decodes a top-level JSON object without any fallback. The
+// "data" or "result" member is decoded into out and any failure is reported
+// as an *errors.DecodeError; a "success": false member yields an
+// *unsuccessfulError. Objects without an envelope are decoded as a whole.
+func decodeStrict(dec *json.Decoder, out any, opts decodeOptions) error {
+	if _, err := dec.Token(); err != nil {
+		return newDecodeError("", err)
+	}
+	var (
+		members   []member
+		found     bool
+		success   = true
+		decodeErr error
+	)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return newDecodeError("", err)
+		}
+		key, _ := tok.(string)
+		if !found && (key == "data" || key == "result") {
+			found = true
+			if err := dec.Decode(out); err != nil {
+				var syntaxErr *json.SyntaxError
+				if errors.As(err, &syntaxErr) {
+					return newDecodeError(key, err)
 				}
+				// The value was consumed; keep reading so that a
+				// "success": false member still takes precedence.
+				decodeErr = newDecodeError(key, err)
 			}
+			continue
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return newDecodeError(key, err)
+		}
+		members = append(members, member{key: key, raw: raw})
+		if key == "success" && string(raw) == "false" {
+			success = false
 		}
 	}
 
-	// Fall back to direct unmarshal.
+	switch {
+	case !success:
+		return &unsuccessfulError{body: joinMembers(members)}
+	case decodeErr != nil:
+		return decodeErr
+	case found:
+		return nil
+	}
+
+	if err := opts.newDecoder(bytes.NewReader(joinMembers(members))).Decode(out); err != nil {
+		return newDecodeError("", err)
+	}
+	return nil
+}
+
+// newDecodeError wraps an encoding/json error with the JSON path of the
+// offending value, prefixed by the envelope key.
+func newDecodeError(key string, err error) *astroerrors.DecodeError {
+	path := key
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		path = joinPath(path, typeErr.Field)
+	} else if m := unknownFieldRe.FindStringSubmatch(err.Error()); m != nil {
+		path = joinPath(path, m[1])
+	}
+	return &astroerrors.DecodeError{Path: path, Err: err}
+}
+
+var unknownFieldRe = regexp.MustCompile(`unknown field "([^"]*)"`)
+
+func joinPath(prefix, field string) string {
+	if prefix == "" {
+		return field
+	}
+	return prefix + "." + field
+}
+
+// member is a top-level object member that was not decoded into out.
+type member struct {
+	key string
+	raw json.RawMessage
+}
+
+// decodeEnvelope decodes the first "data" or "result" member of a top-level
+// JSON object into out. If there is no such member, or its value cannot be
+// decoded into out, found is false and members holds every member of the
+// object so the caller can fall back to decoding the object as a whole.
+func decodeEnvelope(dec *json.Decoder, out any) (found bool, members []member, err error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return false, nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return false, nil, fmt.Errorf("expected JSON object, got %v", tok)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return false, nil, err
+		}
+		key, _ := tok.(string)
+		envelope := key == "data" || key == "result"
+		start := peekValueStart(dec)
+		if envelope && start != 0 && accepts(out, start) {
+			if err := dec.Decode(out); err == nil {
+				return true, nil, nil
+			}
+			// The value is consumed; keep scanning for the fallback.
+			continue
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return false, nil, err
+		}
+		// The value started beyond the decoder's buffer, so its kind was
+		// unknown; try it as the envelope now that it is buffered.
+		if envelope && start == 0 && json.Unmarshal(raw, out) == nil {
+			return true, nil, nil
+		}
+		members = append(members, member{key: key, raw: raw})
+	}
+	return false, members, nil
+}
+
+// joinMembers re-assembles object members into a JSON object.
+func joinMembers(members []member) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(m.key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(m.raw)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
+
+// peekNonSpace returns the first non-whitespace byte of r without consuming it.
+func peekNonSpace(r *bufio.Reader) (byte, error) {
+	for {
+		b, err := r.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		switch b[0] {
+		case ' ', '\t', '\n', '\r':
+			_, _ = r.ReadByte()
+		default:
+			return b[0], nil
+		}
+	}
+}
+
+// peekValueStart returns the first byte of the next value in dec's buffer,
+// or 0 if it has not been read yet.
+func peekValueStart(dec *json.Decoder) byte {
+	r := dec.Buffered()
+	var b [1]byte
+	for {
+		if n, _ := r.Read(b[:]); n == 0 {
+			return 0
+		}
+		switch b[0] {
+		case ' ', '\t', '\n', '\r', ':':
+		default:
+			return b[0]
+		}
+	}
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// accepts reports whether a JSON value starting with the byte start can be
+// decoded into out without a top-level type mismatch. An unknown start (0)
+// is never accepted.
+func accepts(out any, start byte) bool {
+	t := reflect.TypeOf(out)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return false
+	}
+	for t.Kind() == reflect.Ptr {
+		if t.Implements(jsonUnmarshalerType) {
+			return start != 0
+		}
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return start != 0
+	}
+
+	switch k := t.Kind(); {
+	case start == 0:
+		return false
+	case start == 'n' || k == reflect.Interface:
+		return true
+	case start == '{':
+		return k == reflect.Map || k == reflect.Struct
+	case start == '[':
+		return k == reflect.Slice || k == reflect.Array
+	case start == '"':
+		return k == reflect.String || reflect.PointerTo(t).Implements(textUnmarshalerType) ||
+			(k == reflect.Slice && t.Elem().Kind() == reflect.Uint8)
+	case start == 't' || start == 'f':
+		return k == reflect.Bool
+	default: // number
+		return k >= reflect.Int && k <= reflect.Float64
+	}
+}
+
+// unmarshalDirect unmarshals the whole body into out.
+func unmarshalDirect(data []byte, out any) error {
 	if err := json.Unmarshal(data, out); err != nil {
 		return fmt.Errorf("unmarshalling response: %w", err)
 	}
 	return nil
 }
 
-// buildHTTPClient creates an http.Client with AuthTransport wrapping RetryTransport.
-func buildHTTPClient(cfg *requestconfig.RequestConfig) *http.Client {
-	var base http.RoundTripper = http.DefaultTransport
-	if cfg.HTTPClient != nil {
-		base = cfg.HTTPClient.Transport
-		if base == nil {
-			base = http.DefaultTransport
+// maxIdleConnsPerHost raises net/http's default of 2 so that concurrent
+// requests to the API keep their connections alive between calls.
+const maxIdleConnsPerHost = 64
+
+// baseTransport returns the RoundTripper that actually sends requests: the
+// transport of cfg.HTTPClient if set, otherwise a new pooled transport.
+func baseTransport(cfg *requestconfig.RequestConfig) http.RoundTripper {
+	if cfg.HTTPClient != nil && cfg.HTTPClient.Transport != nil {
+		return cfg.HTTPClient.Transport
+	}
+	if dt, ok := http.DefaultTransport.(*http.Transport); ok {
+		t := dt.Clone()
+		t.MaxIdleConnsPerHost = maxIdleConnsPerHost
+		return t
+	}
+	return http.DefaultTransport
+}
+
+// sameTransportSettings reports whether a and b produce identical transport stacks.
+func sameTransportSettings(a, b *requestconfig.RequestConfig) bool {
+	return a.APIKey == b.APIKey &&
+		sameProvider(a.Credentials, b.Credentials) &&
+		a.HTTPClient == b.HTTPClient &&
+		a.MaxRetries == b.MaxRetries &&
+		a.RetryDelay == b.RetryDelay &&
+		a.RetryJitter == b.RetryJitter &&
+		a.AttemptTimeout == b.AttemptTimeout &&
+		a.Endpoints == b.Endpoints &&
+		a.Breaker == b.Breaker &&
+		a.RateLimiter == b.RateLimiter &&
+		a.FailoverThreshold == b.FailoverThreshold &&
+		a.FailoverCoolOff == b.FailoverCoolOff &&
+		slices.Equal(a.RetryStatusCodes, b.RetryStatusCodes)
+}
+
+// sameProvider reports whether a and b are the same credentials provider.
+// Providers of uncomparable types are never considered the same.
+func sameProvider(a, b credentials.Provider) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	ta := reflect.TypeOf(a)
+	return ta == reflect.TypeOf(b) && ta.Comparable() && a == b
+}
+
+// buildHTTPClient creates an http.Client with RetryTransport wrapping
+// AuthTransport around the given base transport, so that each attempt asks
+// the credentials provider for a key. With a rate limiter, each attempt
+// waits for a token. With several base URLs a FailoverTransport picks the
+// endpoint of each attempt.
+func buildHTTPClient(cfg *requestconfig.RequestConfig, base http.RoundTripper) *http.Client {
+	if cfg.Endpoints != nil {
+		base = &transport.FailoverTransport{
+			Endpoints: cfg.Endpoints,
+			Threshold: cfg.FailoverThreshold,
+			CoolOff:   cfg.FailoverCoolOff,
+			Base:      base,
 		}
 	}
 
+	var attempt http.RoundTripper = &transport.AuthTransport{
+		APIKey:      cfg.APIKey,
+		Credentials: cfg.Credentials,
+		Base:        base,
+	}
+	if cfg.RateLimiter != nil {
+		// Inside the retry loop, so that every attempt takes a token.
+		attempt = &transport.RateLimitTransport{Limiter: cfg.RateLimiter, Base: attempt}
+	}
+
 	retry := &transport.RetryTransport{
-		Base:             base,
+		Base:             attempt,
 		MaxRetries:       cfg.MaxRetries,
 		InitialDelay:     cfg.RetryDelay,
 		RetryStatusCodes: cfg.RetryStatusCodes,
+		Jitter:           cfg.RetryJitter,
+		AttemptTimeout:   cfg.AttemptTimeout,
 	}
 
-	auth := &transport.AuthTransport{
-		APIKey: cfg.APIKey,
-		Base:   retry,
+	var rt http.RoundTripper = retry
+	if cfg.Breaker != nil {
+		rt = &transport.BreakerTransport{Breaker: cfg.Breaker, Base: retry}
 	}
 
-	timeout := cfg.RequestTimeout
-	if timeout == 0 {
-		timeout = 30 * time.Second
-	}
-
-	return &http.Client{
-		Transport: auth,
-		Timeout:   timeout,
+	// Deadlines come from the request context; see MakeRequest. Other
+	// settings of a custom client, such as its Timeout, Jar and
+	// CheckRedirect, are kept.
+	if cfg.HTTPClient != nil {
+		c := *cfg.HTTPClient
+		c.Transport = rt
+		return &c
 	}
+	return &http.Client{Transport: rt}
 }
 
-// encodeQueryParams converts a struct to url.Values using json tags or `url` tags.
-// Uses JSON marshalling as an intermediate step for simplicity.
-func encodeQueryParams(params any) (url.Values, error) {
-	if params == nil {
-		return nil, nil
-	}
+var (
+	astrologyOptionsType = reflect.TypeFor[*shared.AstrologyOptions]()
+	reportOptionsType    = reflect.TypeFor[*shared.ReportOptions]()
+)
 
-	// Marshal to JSON map, then to url.Values.
-	data, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
+// withDefaultOptions returns params with the default chart and report
+// options of cfg merged into its *shared.AstrologyOptions and
+// *shared.ReportOptions fields. A nil field gets a copy of the defaults; in a
+// set field only unset values are filled in, so explicit values always win.
+// params itself is left unchanged; a copy is returned if a default was
+// applied.
+func withDefaultOptions(params any, cfg *requestconfig.RequestConfig) any {
+	defaults := map[reflect.Type]reflect.Value{}
+	if cfg.DefaultAstrologyOptions != nil {
+		defaults[astrologyOptionsType] = reflect.ValueOf(cfg.DefaultAstrologyOptions)
+	}
+	if cfg.DefaultReportOptions != nil {
+		defaults[reportOptionsType] = reflect.ValueOf(cfg.DefaultReportOptions)
+	}
+	if params == nil || len(defaults) == 0 {
+		return params
+	}
+	v := reflect.ValueOf(params)
+	isPtr := v.Kind() == reflect.Ptr
+	if isPtr {
+		if v.IsNil() {
+			return params
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return params
 	}
 
-	var m map[string]any
-	if err := json.Unmarshal(data, &m); err != nil {
-		return nil, err
+	var cp reflect.Value
+	for i := 0; i < v.NumField(); i++ {
+		def, ok := defaults[v.Field(i).Type()]
+		if !ok || !v.Type().Field(i).IsExported() {
+			continue
+		}
+		merged := reflect.New(def.Type().Elem())
+		if f := v.Field(i); !f.IsNil() {
+			merged.Elem().Set(f.Elem())
+		}
+		mergeZero(merged.Elem(), def.Elem())
+		if !cp.IsValid() {
+			cp = reflect.New(v.Type()).Elem()
+			cp.Set(v)
+		}
+		cp.Field(i).Set(merged)
+	}
+	if !cp.IsValid() {
+		return params
 	}
+	if isPtr {
+		return cp.Addr().Interface()
+	}
+	return cp.Interface()
+}
 
-	values := url.Values{}
-	flattenQueryParams(values, m)
-	return values, nil
+// presence is implemented by shared.Field.
+type presence interface {
+	IsPresent() bool
 }
 
-func flattenQueryParams(values url.Values, m map[string]any) {
-	for k, v := range m {
-		if v == nil {
+// mergeZero sets each unset field of the struct dst to the matching field of
+// src, descending into nested structs. A shared.Field is unset unless it is
+// present, so an explicit zero or false wins; other fields are unset when
+// they are the zero value.
+func mergeZero(dst, src reflect.Value) {
+	for i := 0; i < dst.NumField(); i++ {
+		f := dst.Field(i)
+		if !f.CanSet() {
 			continue
 		}
-		rv := reflect.ValueOf(v)
-		switch rv.Kind() {
-		case reflect.Slice, reflect.Array:
-			for i := 0; i < rv.Len(); i++ {
-				values.Add(k, fmt.Sprintf("%v", rv.Index(i).Interface()))
+		if p, ok := f.Interface().(presence); ok {
+			if !p.IsPresent() {
+				f.Set(src.Field(i))
 			}
-		default:
-			values.Set(k, fmt.Sprintf("%v", v))
+			continue
+		}
+		switch {
+		case f.Kind() == reflect.Struct:
+			mergeZero(f, src.Field(i))
+		case f.IsZero():
+			f.Set(src.Field(i))
 		}
 	}
 }
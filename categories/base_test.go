@@ -0,0 +1,224 @@
+package categories_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/astro-api/astroapi-go/categories"
+	astroerrors "github.com/astro-api/astroapi-go/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalResponse_DataEnvelope(t *testing.T) {
+	var out map[string]any
+	err := categories.UnmarshalResponse(strings.NewReader(`{"success":true,"data":{"sun":"Taurus"}}`), &out)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"sun": "Taurus"}, out)
+}
+
+func TestUnmarshalResponse_ResultEnvelope(t *testing.T) {
+	var out map[string]any
+	err := categories.UnmarshalResponse(strings.NewReader(`{"meta":{"nested":[1,2,{"a":"b"}]},"result":{"moon":"Leo"}}`), &out)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"moon": "Leo"}, out)
+}
+
+func TestUnmarshalResponse_NoEnvelope(t *testing.T) {
+	var out map[string]any
+	err := categories.UnmarshalResponse(strings.NewReader(`{"sun":"Taurus","moon":"Leo"}`), &out)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"sun": "Taurus", "moon": "Leo"}, out)
+}
+
+func TestUnmarshalResponse_TopLevelArray(t *testing.T) {
+	var out []string
+	err := categories.UnmarshalResponse(strings.NewReader(`["Fire","Earth"]`), &out)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Fire", "Earth"}, out)
+}
+
+func TestUnmarshalResponse_EnvelopeMismatchFallsBack(t *testing.T) {
+	var out map[string]any
+	err := categories.UnmarshalResponse(strings.NewReader(`{"data":["Fire","Earth"]}`), &out)
+	require.NoError(t, err)
+	assert.Contains(t, out, "data")
+}
+
+func TestUnmarshalResponse_InvalidJSON(t *testing.T) {
+	var out map[string]any
+	err := categories.UnmarshalResponse(strings.NewReader(`not json`), &out)
+	assert.Error(t, err)
+}
+
+// largeEnvelope builds an astrocartography-sized response: many lines, each
+// with a long list of coordinates.
+func largeEnvelope(tb testing.TB) []byte {
+	tb.Helper()
+	lines := make([]map[string]any, 0, 40)
+	for i := 0; i < 40; i++ {
+		coords := make([][2]float64, 0, 720)
+		for j := 0; j < 720; j++ {
+			coords = append(coords, [2]float64{float64(j)/4 - 90, float64(i*9) - 180})
+		}
+		lines = append(lines, map[string]any{
+			"planet":      fmt.Sprintf("planet-%d", i),
+			"angle":       "MC",
+			"coordinates": coords,
+		})
+	}
+	data, err := json.Marshal(map[string]any{
+		"success": true,
+		"data":    map[string]any{"lines": lines},
+	})
+	require.NoError(tb, err)
+	return data
+}
+
+// legacyUnmarshalResponse is the previous two-pass implementation, kept for
+// benchmark comparison. The single pass is a little faster; allocations are
+// about the same, as the decoder buffers the envelope value either way.
+func legacyUnmarshalResponse(body io.Reader, out any) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	var env map[string]json.RawMessage
+	if jsonErr := json.Unmarshal(data, &env); jsonErr == nil {
+		for _, key := range []string{"data", "result"} {
+			if raw, ok := env[key]; ok {
+				if err := json.Unmarshal(raw, out); err == nil {
+					return nil
+				}
+			}
+		}
+	}
+	return json.Unmarshal(data, out)
+}
+
+type mapResponse struct {
+	Lines []struct {
+		Planet      string       `json:"planet"`
+		Angle       string       `json:"angle"`
+		Coordinates [][2]float64 `json:"coordinates"`
+	} `json:"lines"`
+}
+
+func BenchmarkUnmarshalResponse_Streaming(b *testing.B) {
+	data := largeEnvelope(b)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var out mapResponse
+		if err := categories.UnmarshalResponse(bytes.NewReader(data), &out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalResponse_Legacy(b *testing.B) {
+	data := largeEnvelope(b)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var out mapResponse
+		if err := legacyUnmarshalResponse(bytes.NewReader(data), &out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestUnmarshalResponse_EnvelopeAfterLargeMember(t *testing.T) {
+	var out map[string]any
+	body := `{"meta":"` + strings.Repeat("x", 8192) + `","data":{"sun":"Taurus"}}`
+	err := categories.UnmarshalResponse(strings.NewReader(body), &out)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"sun": "Taurus"}, out)
+}
+
+func TestUnmarshalResponse_ChunkedReader(t *testing.T) {
+	body := `{"success":true,"data":{"a":1}}`
+
+	var m map[string]any
+	require.NoError(t, categories.UnmarshalResponse(iotest.OneByteReader(strings.NewReader(body)), &m))
+	assert.Equal(t, map[string]any{"a": float64(1)}, m)
+
+	var s struct {
+		A int `json:"a"`
+	}
+	require.NoError(t, categories.UnmarshalResponse(iotest.OneByteReader(strings.NewReader(body)), &s))
+	assert.Equal(t, 1, s.A)
+}
+
+func TestUnmarshalResponse_EnvelopeAtBufferBoundary(t *testing.T) {
+	// The decoder reads in chunks; whatever the padding, the "data" key ends
+	// at a chunk boundary for some lengths.
+	for n := 0; n < 4096; n++ {
+		body := `{"meta":"` + strings.Repeat("x", n) + `","data":{"a":1}}`
+		var out struct {
+			A int `json:"a"`
+		}
+		require.NoError(t, categories.UnmarshalResponse(strings.NewReader(body), &out))
+		require.Equal(t, 1, out.A, "padding %d", n)
+	}
+}
+
+func TestUnmarshalResponse_DrainsTrailingMembers(t *testing.T) {
+	// A body left unread keeps its connection from being reused.
+	r := strings.NewReader(`{"data":{"a":1},"meta":"` + strings.Repeat("x", 1<<20) + `"}`)
+	var out map[string]any
+	require.NoError(t, categories.UnmarshalResponse(r, &out))
+	assert.Equal(t, map[string]any{"a": float64(1)}, out)
+	assert.Zero(t, r.Len())
+}
+
+func TestUnmarshalResponse_ArrayEnvelopeIntoSlice(t *testing.T) {
+	var out []string
+	err := categories.UnmarshalResponse(strings.NewReader(`{"data":["Fire","Earth"]}`), &out)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Fire", "Earth"}, out)
+}
+
+func TestUnmarshalResponseStrict_TypeMismatchHasPath(t *testing.T) {
+	var out mapResponse
+	err := categories.UnmarshalResponseStrict(strings.NewReader(`{"data":{"lines":[{"planet":"Sun","coordinates":[[1,2]]},{"planet":7}]}}`), &out, false)
+	require.Error(t, err)
+	var decErr *astroerrors.DecodeError
+	require.ErrorAs(t, err, &decErr)
+	assert.Contains(t, decErr.Path, "data.lines")
+	assert.Contains(t, decErr.Path, "planet")
+}
+
+func TestUnmarshalResponseStrict_EnvelopeKindMismatch(t *testing.T) {
+	var out map[string]any
+	err := categories.UnmarshalResponseStrict(strings.NewReader(`{"data":["Fire","Earth"]}`), &out, false)
+	var decErr *astroerrors.DecodeError
+	require.ErrorAs(t, err, &decErr)
+	assert.Equal(t, "data", decErr.Path)
+	assert.Nil(t, out)
+}
+
+func TestUnmarshalResponseStrict_UnknownFields(t *testing.T) {
+	body := `{"data":{"lines":[{"planet":"Sun","house":10}]}}`
+
+	var lenient mapResponse
+	require.NoError(t, categories.UnmarshalResponseStrict(strings.NewReader(body), &lenient, false))
+
+	var strict mapResponse
+	err := categories.UnmarshalResponseStrict(strings.NewReader(body), &strict, true)
+	var decErr *astroerrors.DecodeError
+	require.ErrorAs(t, err, &decErr)
+	assert.Equal(t, "data.house", decErr.Path)
+}
+
+func TestUnmarshalResponseStrict_OK(t *testing.T) {
+	var out map[string]any
+	err := categories.UnmarshalResponseStrict(strings.NewReader(`{"success":true,"data":{"sun":"Taurus"}}`), &out, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"sun": "Taurus"}, out)
+}
@@ -1,14 +1,23 @@
 package charts_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"log/slog"
 	"net/http"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	astroapi "github.com/astro-api/astroapi-go"
+	"github.com/astro-api/astroapi-go/cache"
 	"github.com/astro-api/astroapi-go/categories/charts"
+	astroerrors "github.com/astro-api/astroapi-go/errors"
 	"github.com/astro-api/astroapi-go/internal/testutil"
 	"github.com/astro-api/astroapi-go/option"
+	"github.com/astro-api/astroapi-go/shared"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -30,11 +39,283 @@ func TestChartsClient_GetNatal(t *testing.T) {
 	assert.NotNil(t, result)
 }
 
+func TestChartsClient_GetNatal_Cached(t *testing.T) {
+	var calls int32
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		testutil.JSON(w, testutil.DataEnvelope(map[string]any{"chart_type": "natal"}))
+	}, option.WithCache(cache.NewLRU(10)), option.WithCacheTTL(time.Minute))
+	defer cleanup()
+
+	subject := testutil.DefaultSubject()
+	for i := 0; i < 2; i++ {
+		_, err := client.Charts.GetNatal(ctx, charts.NatalChartParams{Subject: subject})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// A different subject is a different cache entry.
+	_, err := client.Charts.GetNatal(ctx, charts.NatalChartParams{Subject: testutil.DefaultSubject2()})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestChartsClient_GetNatal_CacheKeyCoversMiddlewareHeaders(t *testing.T) {
+	type tenantKey struct{}
+	var calls int32
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		testutil.JSON(w, testutil.DataEnvelope(map[string]any{"tenant": r.Header.Get("X-Tenant")}))
+	}, option.WithCache(cache.NewLRU(10)), option.WithCacheTTL(time.Minute),
+		option.WithMiddleware(func(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+			req.Header.Set("X-Tenant", req.Context().Value(tenantKey{}).(string))
+			return next(req)
+		}))
+	defer cleanup()
+
+	params := charts.NatalChartParams{Subject: testutil.DefaultSubject()}
+	for _, tenant := range []string{"acme", "globex", "acme"} {
+		result, err := client.Charts.GetNatal(context.WithValue(ctx, tenantKey{}, tenant), params)
+		require.NoError(t, err)
+		assert.Equal(t, tenant, (*result)["tenant"])
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls), "each tenant has its own entry")
+}
+
+func TestChartsClient_GetNatal_IdempotencyKey(t *testing.T) {
+	var keys []string
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		testutil.JSON(w, testutil.DataEnvelope(map[string]any{"chart_type": "natal"}))
+	}, option.WithMaxRetries(1), option.WithRetryDelay(time.Millisecond))
+	defer cleanup()
+
+	params := charts.NatalChartParams{Subject: testutil.DefaultSubject()}
+	_, err := client.Charts.GetNatal(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, keys[0])
+	assert.Equal(t, keys[0], keys[1], "retries reuse the key")
+
+	_, err = client.Charts.GetNatal(ctx, params)
+	require.NoError(t, err)
+	assert.NotEqual(t, keys[0], keys[2], "each call gets a new key")
+
+	_, err = client.Charts.GetNatal(ctx, params, option.WithIdempotencyKey("natal-42"))
+	require.NoError(t, err)
+	assert.Equal(t, "natal-42", keys[3])
+}
+
+func TestChartsClient_GetNatal_DefaultOptions(t *testing.T) {
+	var gotBody map[string]any
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		testutil.JSON(w, testutil.DataEnvelope(map[string]any{"chart_type": "natal"}))
+	}, option.WithDefaultAstrologyOptions(shared.AstrologyOptions{
+		HouseSystem:  "P",
+		ZodiacType:   "Tropic",
+		Language:     "de",
+		ActivePoints: []string{"Sun", "Moon"},
+	}))
+	defer cleanup()
+
+	_, err := client.Charts.GetNatal(ctx, charts.NatalChartParams{Subject: testutil.DefaultSubject()})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"house_system":  "P",
+		"zodiac_type":   "Tropic",
+		"language":      "de",
+		"active_points": []any{"Sun", "Moon"},
+	}, gotBody["options"])
+
+	// Explicit values win; the rest is filled from the defaults.
+	explicit := &shared.AstrologyOptions{Language: "en", Precision: shared.F(2)}
+	_, err = client.Charts.GetNatal(ctx, charts.NatalChartParams{Subject: testutil.DefaultSubject(), Options: explicit})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"house_system":  "P",
+		"zodiac_type":   "Tropic",
+		"language":      "en",
+		"precision":     float64(2),
+		"active_points": []any{"Sun", "Moon"},
+	}, gotBody["options"])
+	assert.Equal(t, &shared.AstrologyOptions{Language: "en", Precision: shared.F(2)}, explicit, "params are not modified")
+}
+
+func TestChartsClient_GetNatal_DefaultOptions_ExplicitZeroWins(t *testing.T) {
+	var gotBody map[string]any
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		testutil.JSON(w, testutil.DataEnvelope(map[string]any{"chart_type": "natal"}))
+	}, option.WithDefaultAstrologyOptions(shared.AstrologyOptions{
+		Precision:              shared.F(4),
+		IncludeInterpretations: shared.F(true),
+		IncludeRawData:         shared.F(true),
+	}))
+	defer cleanup()
+
+	_, err := client.Charts.GetNatal(ctx, charts.NatalChartParams{
+		Subject: testutil.DefaultSubject(),
+		Options: &shared.AstrologyOptions{Precision: shared.F(0), IncludeInterpretations: shared.F(false)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"precision":               float64(0),
+		"include_interpretations": false,
+		"include_raw_data":        true,
+	}, gotBody["options"])
+}
+
+func TestChartsClient_GetNatal_Hooks(t *testing.T) {
+	var calls int32
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			testutil.JSON(w, testutil.DataEnvelope(map[string]any{"chart_type": "natal"}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}, option.WithMaxRetries(1), option.WithRetryDelay(time.Millisecond), option.WithRetryJitter(option.JitterNone))
+	defer cleanup()
+
+	var (
+		events    []string
+		attempts  []option.AttemptEvent
+		responded option.ResponseEvent
+		failed    option.ErrorEvent
+	)
+	client = client.WithOptions(option.WithHooks(option.Hooks{
+		OnRequestStart: func(e option.RequestEvent) { events = append(events, "start "+e.Endpoint) },
+		OnAttempt: func(e option.AttemptEvent) {
+			events = append(events, "attempt "+e.Endpoint)
+			attempts = append(attempts, e)
+		},
+		OnResponse: func(e option.ResponseEvent) { events = append(events, "response "+e.Endpoint); responded = e },
+		OnError:    func(e option.ErrorEvent) { events = append(events, "error "+e.Endpoint); failed = e },
+	}))
+	params := charts.NatalChartParams{Subject: testutil.DefaultSubject()}
+
+	_, err := client.Charts.GetNatal(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"start charts.GetNatal", "attempt charts.GetNatal", "attempt charts.GetNatal", "response charts.GetNatal"}, events)
+	require.Len(t, attempts, 2)
+	assert.Equal(t, 1, attempts[0].Attempt)
+	assert.Equal(t, http.StatusServiceUnavailable, attempts[0].StatusCode)
+	assert.Zero(t, attempts[0].Delay)
+	assert.Equal(t, 2, attempts[1].Attempt)
+	assert.Equal(t, time.Millisecond, attempts[1].Delay)
+	assert.Equal(t, http.MethodPost, responded.Method)
+	assert.Equal(t, http.StatusOK, responded.StatusCode)
+	assert.Equal(t, 2, responded.Attempts)
+
+	events = nil
+	_, err = client.Charts.GetNatal(ctx, params, option.WithMaxRetries(0))
+	require.Error(t, err)
+	assert.Equal(t, []string{"start charts.GetNatal", "attempt charts.GetNatal", "error charts.GetNatal"}, events)
+	assert.Equal(t, http.StatusNotFound, failed.StatusCode)
+	assert.ErrorIs(t, failed.Err, astroerrors.ErrNotFound)
+
+	// Validation errors are reported too.
+	events = nil
+	_, err = client.Charts.GetNatal(ctx, charts.NatalChartParams{})
+	require.Error(t, err)
+	assert.Equal(t, []string{"start charts.GetNatal", "error charts.GetNatal"}, events)
+	assert.ErrorIs(t, failed.Err, astroerrors.ErrValidation)
+}
+
+func TestChartsClient_GetNatal_Logger(t *testing.T) {
+	var calls int32
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		testutil.JSON(w, testutil.DataEnvelope(map[string]any{
+			"subject": map[string]any{"name": "Test User", "birth_data": map[string]any{"latitude": 51.4769}},
+		}))
+	}, option.WithMaxRetries(1), option.WithRetryDelay(time.Millisecond))
+	defer cleanup()
+
+	subject := testutil.DefaultSubject()
+	subject.Email = "test@example.com"
+	subject.BirthData.Latitude, subject.BirthData.Longitude = shared.F(51.4769), shared.F(-0.0005)
+	params := charts.NatalChartParams{Subject: subject}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	_, err := client.Charts.GetNatal(ctx, params,
+		option.WithLogger(logger),
+		option.WithHeader("Authorization", "Bearer sk-secret"),
+	)
+	require.NoError(t, err)
+
+	var messages []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		messages = append(messages, entry["level"].(string)+" "+entry["msg"].(string))
+		if entry["msg"] == "astroapi request" {
+			assert.Equal(t, "charts.GetNatal", entry["endpoint"])
+			assert.Equal(t, float64(2), entry["attempts"])
+		}
+	}
+	assert.Equal(t, []string{
+		"DEBUG astroapi request body",
+		"DEBUG astroapi attempt",
+		"WARN astroapi retry",
+		"DEBUG astroapi response body",
+		"INFO astroapi request",
+	}, messages)
+	assert.NotContains(t, buf.String(), "sk-secret")
+	assert.Contains(t, buf.String(), "test@example.com")
+
+	buf.Reset()
+	_, err = client.Charts.GetNatal(ctx, params, option.WithLogger(logger), option.WithLogRedactPII())
+	require.NoError(t, err)
+	for _, pii := range []string{"Test User", "test@example.com", "51.4769", "-0.0005"} {
+		assert.NotContains(t, buf.String(), pii)
+	}
+	assert.Contains(t, buf.String(), "London")
+
+	// Bodies are only logged at debug level.
+	buf.Reset()
+	infoLogger := slog.New(slog.NewJSONHandler(&buf, nil))
+	_, err = client.Charts.GetNatal(ctx, params, option.WithLogger(infoLogger))
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "Test User")
+	assert.Contains(t, buf.String(), `"msg":"astroapi request"`)
+}
+
 func TestChartsClient_GetNatal_ValidationError(t *testing.T) {
 	client := astroapi.NewClient(option.WithAPIKey("test-key"), option.WithMaxRetries(0))
 	_, err := client.Charts.GetNatal(ctx, charts.NatalChartParams{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "validation")
+
+	var verr *astroerrors.ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Violations, 1)
+	assert.Equal(t, "subject", verr.Violations[0].Path)
+	assert.ErrorIs(t, err, astroerrors.ErrValidation)
+}
+
+func TestChartsClient_GetNatal_PlaceRequired(t *testing.T) {
+	client := astroapi.NewClient(option.WithAPIKey("test-key"), option.WithMaxRetries(0))
+	subject := testutil.DefaultSubject()
+	subject.BirthData.City = ""
+
+	_, err := client.Charts.GetNatal(ctx, charts.NatalChartParams{Subject: subject})
+	var verr *astroerrors.ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Violations, 1)
+	assert.Equal(t, "subject", verr.Violations[0].Path)
+	assert.Equal(t, "place", verr.Violations[0].Rule)
 }
 
 func TestChartsClient_GetSynastry(t *testing.T) {
@@ -106,7 +387,7 @@ func TestChartsClient_GetLunarReturn(t *testing.T) {
 
 	result, err := client.Charts.GetLunarReturn(ctx, charts.LunarReturnParams{
 		Subject:    testutil.DefaultSubject(),
-		ReturnDate: "2024-05-11",
+		ReturnDate: shared.NewDate(2024, 5, 11),
 	})
 	require.NoError(t, err)
 	assert.NotNil(t, result)
@@ -129,13 +410,16 @@ func TestChartsClient_GetNatalTransits(t *testing.T) {
 func TestChartsClient_GetProgressions(t *testing.T) {
 	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/api/v3/charts/progressions", r.URL.Path)
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "2024-05-11", body["target_date"])
 		testutil.JSON(w, testutil.DataEnvelope(map[string]any{"chart_type": "progression"}))
 	})
 	defer cleanup()
 
 	result, err := client.Charts.GetProgressions(ctx, charts.ProgressionParams{
 		Subject:         testutil.DefaultSubject(),
-		TargetDate:      "2024-05-11",
+		TargetDate:      shared.NewDate(2024, 5, 11),
 		ProgressionType: "secondary",
 	})
 	require.NoError(t, err)
@@ -151,7 +435,7 @@ func TestChartsClient_GetDirections(t *testing.T) {
 
 	result, err := client.Charts.GetDirections(ctx, charts.DirectionParams{
 		Subject:       testutil.DefaultSubject(),
-		TargetDate:    "2024-05-11",
+		TargetDate:    shared.NewDate(2024, 5, 11),
 		DirectionType: "solar_arc",
 	})
 	require.NoError(t, err)
@@ -182,7 +466,7 @@ func TestChartsClient_GetLunarReturnTransits(t *testing.T) {
 
 	result, err := client.Charts.GetLunarReturnTransits(ctx, charts.LunarReturnTransitsParams{
 		Subject:    testutil.DefaultSubject(),
-		ReturnDate: "2024-05-11",
+		ReturnDate: shared.NewDate(2024, 5, 11),
 	})
 	require.NoError(t, err)
 	assert.NotNil(t, result)
@@ -4,6 +4,7 @@ package data
 
 import (
 	"context"
+	"time"
 
 	"github.com/astro-api/astroapi-go/categories"
 	"github.com/astro-api/astroapi-go/option"
@@ -11,6 +12,10 @@ import (
 
 const apiPrefix = "api/v3/data"
 
+// nowCacheTTL is how long GetNow responses are cached when a cache is configured.
+// Positions move continuously, so the window is kept short.
+const nowCacheTTL = 10 * time.Second
+
 // Client provides access to the /api/v3/data endpoints.
 type Client struct {
 	*categories.BaseCategoryClient
@@ -24,7 +29,7 @@ func NewClient(base *categories.BaseCategoryClient) *Client {
 // GetNow returns the current moment's astrological data.
 func (c *Client) GetNow(ctx context.Context, opts ...option.RequestOption) (*NowResponse, error) {
 	var out NowResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "now"), nil, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "now"), nil, &out, categories.WithDefaultCacheTTL(nowCacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -3,9 +3,13 @@ package data_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	astroapi "github.com/astro-api/astroapi-go"
 	"github.com/astro-api/astroapi-go/categories/data"
@@ -87,6 +91,48 @@ func TestDataClient_GetPositions_APIError(t *testing.T) {
 	assert.Equal(t, "UNAUTHORIZED", apiErr.Code)
 }
 
+func TestDataClient_GetPositions_StrictUnsuccessful(t *testing.T) {
+	if testutil.IsIntegration() {
+		t.Skip("skipping mock-only error test in integration mode")
+	}
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		testutil.JSON(w, map[string]any{
+			"success": false,
+			"error": map[string]any{
+				"error_code": "CALCULATION_FAILED",
+				"message":    "Ephemeris out of range",
+			},
+		})
+	}, option.WithStrictDecoding())
+	defer cleanup()
+
+	_, err := client.Data.GetPositions(ctx, data.PositionsParams{
+		Subject: testutil.DefaultSubject(),
+	})
+	var apiErr *astroerrors.AstrologyError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusOK, apiErr.StatusCode)
+	assert.Equal(t, "CALCULATION_FAILED", apiErr.Code)
+	assert.Equal(t, "Ephemeris out of range", apiErr.Message)
+}
+
+func TestDataClient_GetPositions_StrictDecodeError(t *testing.T) {
+	if testutil.IsIntegration() {
+		t.Skip("skipping mock-only error test in integration mode")
+	}
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		testutil.JSON(w, testutil.DataEnvelope([]any{"not", "an", "object"}))
+	}, option.WithStrictDecoding())
+	defer cleanup()
+
+	_, err := client.Data.GetPositions(ctx, data.PositionsParams{
+		Subject: testutil.DefaultSubject(),
+	})
+	var decErr *astroerrors.DecodeError
+	require.ErrorAs(t, err, &decErr)
+	assert.Equal(t, "data", decErr.Path)
+}
+
 // ---- GetEnhancedPositions -------------------------------------------------
 
 func TestDataClient_GetEnhancedPositions(t *testing.T) {
@@ -307,8 +353,158 @@ func TestDataClient_ResponseInto(t *testing.T) {
 	assert.Equal(t, "test-req-123", rawResp.Header.Get("X-Request-Id"))
 }
 
+func TestDataClient_ResponseInto_BodyReadable(t *testing.T) {
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		testutil.JSON(w, testutil.DataEnvelope(map[string]any{"current_time": "now"}))
+	})
+	defer cleanup()
+
+	var rawResp *http.Response
+	_, err := client.Data.GetNow(ctx, option.WithResponseInto(&rawResp))
+	require.NoError(t, err)
+	body, err := io.ReadAll(rawResp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "current_time")
+}
+
+// ---- ResponseMeta ---------------------------------------------------------
+
+func TestDataClient_ResponseMeta(t *testing.T) {
+	var calls int32
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("X-Request-Id", "req-42")
+		w.Header().Set("X-RateLimit-Remaining", "17")
+		w.Header().Set("X-RateLimit-Reset", "30")
+		testutil.JSON(w, testutil.DataEnvelope(map[string]any{"current_time": "now"}))
+	}, option.WithMaxRetries(1), option.WithRetryDelay(time.Millisecond))
+	defer cleanup()
+
+	var meta option.ResponseMeta
+	_, err := client.Data.GetNow(ctx, option.WithResponseMeta(&meta))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, meta.StatusCode)
+	assert.Equal(t, "req-42", meta.RequestID)
+	assert.Equal(t, 17, meta.RateLimitRemaining)
+	assert.Equal(t, 30*time.Second, meta.RateLimitReset)
+	assert.Equal(t, 2, meta.Attempts)
+	assert.Len(t, meta.AttemptLatencies, 2)
+	assert.GreaterOrEqual(t, meta.Latency, meta.AttemptLatencies[1])
+	assert.Contains(t, string(meta.Body), "current_time")
+	assert.False(t, meta.Cached)
+}
+
+func TestDataClient_ResponseMeta_Error(t *testing.T) {
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-err")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"bad"}`))
+	})
+	defer cleanup()
+
+	var meta option.ResponseMeta
+	_, err := client.Data.GetNow(ctx, option.WithResponseMeta(&meta))
+	require.Error(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, meta.StatusCode)
+	assert.Equal(t, "req-err", meta.RequestID)
+	assert.Equal(t, -1, meta.RateLimitRemaining)
+	assert.Equal(t, 1, meta.Attempts)
+	assert.JSONEq(t, `{"message":"bad"}`, string(meta.Body))
+}
+
 // ---- Integration-only: smoke test all data endpoints ---------------------
 
+func TestDataClient_Middleware(t *testing.T) {
+	var calls int32
+	var gotTenant, gotTrace string
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		gotTenant, gotTrace = r.Header.Get("X-Tenant"), r.Header.Get("X-Trace")
+		if atomic.LoadInt32(&calls) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		testutil.JSON(w, testutil.DataEnvelope(map[string]any{}))
+	}, option.WithMaxRetries(1), option.WithRetryDelay(time.Millisecond))
+	defer cleanup()
+
+	var order []string
+	var statuses []int
+	record := func(name string) option.Middleware {
+		return func(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+			order = append(order, name)
+			return next(req)
+		}
+	}
+	audit := func(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+		req.Header.Set("X-Tenant", "acme")
+		resp, err := next(req)
+		if err == nil {
+			statuses = append(statuses, resp.StatusCode)
+		}
+		return resp, err
+	}
+	client = client.WithOptions(option.WithMiddleware(audit, record("client")))
+
+	trace := func(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+		req.Header.Set("X-Trace", "t-1")
+		return next(req)
+	}
+	_, err := client.Data.GetNow(ctx, option.WithMiddleware(record("request"), trace))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"client", "request"}, order)
+	assert.Equal(t, []int{http.StatusOK}, statuses, "middlewares run once around all retries")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, "acme", gotTenant)
+	assert.Equal(t, "t-1", gotTrace)
+
+	// Per-request middlewares do not stick to the client.
+	order = nil
+	_, err = client.Data.GetNow(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"client"}, order)
+}
+
+func TestDataClient_Middleware_ShortCircuit(t *testing.T) {
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the request should not reach the server")
+	})
+	defer cleanup()
+
+	blocked := errors.New("blocked by policy")
+	_, err := client.Data.GetNow(ctx, option.WithMiddleware(func(*http.Request, option.MiddlewareNext) (*http.Response, error) {
+		return nil, blocked
+	}))
+	assert.ErrorIs(t, err, blocked)
+}
+
+func TestDataClient_HTTPClientTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	client := astroapi.NewClient(
+		option.WithAPIKey("test-key"),
+		option.WithBaseURL(srv.URL),
+		option.WithMaxRetries(0),
+		option.WithHTTPClient(&http.Client{Timeout: 20 * time.Millisecond}),
+	)
+	started := time.Now()
+	_, err := client.Data.GetNow(ctx)
+	require.Error(t, err)
+	assert.Less(t, time.Since(started), 500*time.Millisecond, "the custom client's Timeout is kept")
+}
+
 func TestDataClient_Integration_AllEndpoints(t *testing.T) {
 	client := testutil.NewIntegrationClient(t)
 
@@ -4,7 +4,7 @@ import "github.com/astro-api/astroapi-go/shared"
 
 // PositionsParams contains request parameters for planetary positions.
 type PositionsParams struct {
-	Subject shared.Subject    `json:"subject" validate:"required"`
+	Subject shared.Subject    `json:"subject" validate:"required,place"`
 	Options *shared.AstrologyOptions `json:"options,omitempty"`
 }
 
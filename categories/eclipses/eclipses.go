@@ -16,17 +16,17 @@ type GenericResponse map[string]any
 type UpcomingParams struct {
 	Type      string `json:"type,omitempty" url:"type,omitempty"`
 	Limit     int    `json:"limit,omitempty" url:"limit,omitempty"`
-	AfterDate string `json:"after_date,omitempty" url:"after_date,omitempty"`
+	AfterDate string `json:"after_date,omitempty" url:"after_date,omitempty" validate:"date"`
 }
 
 type NatalCheckParams struct {
-	Subject     shared.Subject `json:"subject" validate:"required"`
-	EclipseDate string         `json:"eclipse_date" validate:"required"`
+	Subject     shared.Subject `json:"subject" validate:"required,place"`
+	EclipseDate string         `json:"eclipse_date" validate:"required,date"`
 }
 
 type InterpretationParams struct {
-	EclipseDate string          `json:"eclipse_date" validate:"required"`
-	Subject     *shared.Subject `json:"subject,omitempty"`
+	EclipseDate string          `json:"eclipse_date" validate:"required,date"`
+	Subject     *shared.Subject `json:"subject,omitempty" validate:"place"`
 }
 
 // Client provides access to the /api/v3/eclipses endpoints.
@@ -20,7 +20,7 @@ type GlobalAnalysisParams struct {
 }
 
 type PersonalAnalysisParams struct {
-	Subject shared.Subject `json:"subject" validate:"required"`
+	Subject shared.Subject `json:"subject" validate:"required,place"`
 	Options *shared.AstrologyOptions `json:"options,omitempty"`
 }
 
@@ -0,0 +1,13 @@
+package categories
+
+import "io"
+
+// UnmarshalResponse exposes the default (lenient) decoder for black-box tests.
+func UnmarshalResponse(body io.Reader, out any) error {
+	return unmarshalResponse(body, out, decodeOptions{})
+}
+
+// UnmarshalResponseStrict exposes strict decoding for black-box tests.
+func UnmarshalResponseStrict(body io.Reader, out any, disallowUnknown bool) error {
+	return unmarshalResponse(body, out, decodeOptions{strict: true, disallowUnknown: disallowUnknown})
+}
@@ -3,6 +3,7 @@ package fixedstars
 
 import (
 	"context"
+	"time"
 
 	"github.com/astro-api/astroapi-go/categories"
 	"github.com/astro-api/astroapi-go/option"
@@ -11,22 +12,25 @@ import (
 
 const apiPrefix = "api/v3/fixed-stars"
 
+// listCacheTTL is how long the fixed star catalogue is cached when a cache is configured.
+const listCacheTTL = 12 * time.Hour
+
 type GenericResponse map[string]any
 
 type PositionsParams struct {
-	Subject shared.Subject `json:"subject" validate:"required"`
+	Subject shared.Subject `json:"subject" validate:"required,place"`
 	Preset  string         `json:"preset,omitempty"`
 	Orb     float64        `json:"orb,omitempty"`
 }
 
 type ConjunctionsParams struct {
-	Subject shared.Subject `json:"subject" validate:"required"`
+	Subject shared.Subject `json:"subject" validate:"required,place"`
 	Orb     float64        `json:"orb,omitempty"`
 	Stars   []string       `json:"stars,omitempty"`
 }
 
 type ReportParams struct {
-	Subject shared.Subject `json:"subject" validate:"required"`
+	Subject shared.Subject `json:"subject" validate:"required,place"`
 	Options *shared.ReportOptions `json:"options,omitempty"`
 }
 
@@ -42,7 +46,7 @@ func NewClient(base *categories.BaseCategoryClient) *Client {
 
 func (c *Client) GetList(ctx context.Context, opts ...option.RequestOption) (*GenericResponse, error) {
 	var out GenericResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "list"), nil, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "list"), nil, &out, categories.WithDefaultCacheTTL(listCacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -3,6 +3,7 @@ package glossary
 
 import (
 	"context"
+	"time"
 
 	"github.com/astro-api/astroapi-go/categories"
 	"github.com/astro-api/astroapi-go/option"
@@ -10,6 +11,10 @@ import (
 
 const apiPrefix = "api/v3/glossary"
 
+// cacheTTL is how long glossary responses are cached when a cache is configured.
+// Reference data changes rarely.
+const cacheTTL = 12 * time.Hour
+
 type CitySearchParams struct {
 	Search      string `json:"search,omitempty" url:"search,omitempty"`
 	CountryCode string `json:"country_code,omitempty" url:"country_code,omitempty"`
@@ -47,7 +52,7 @@ func NewClient(base *categories.BaseCategoryClient) *Client {
 
 func (c *Client) GetActivePoints(ctx context.Context, params *ActivePointsParams, opts ...option.RequestOption) (*GenericResponse, error) {
 	var out GenericResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "active-points"), params, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "active-points"), params, &out, categories.WithDefaultCacheTTL(cacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -55,7 +60,7 @@ func (c *Client) GetActivePoints(ctx context.Context, params *ActivePointsParams
 
 func (c *Client) GetPrimaryActivePoints(ctx context.Context, params *ActivePointsParams, opts ...option.RequestOption) (*GenericResponse, error) {
 	var out GenericResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "active-points", "primary"), params, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "active-points", "primary"), params, &out, categories.WithDefaultCacheTTL(cacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -63,7 +68,7 @@ func (c *Client) GetPrimaryActivePoints(ctx context.Context, params *ActivePoint
 
 func (c *Client) GetCities(ctx context.Context, params *CitySearchParams, opts ...option.RequestOption) (*GenericResponse, error) {
 	var out GenericResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "cities"), params, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "cities"), params, &out, categories.WithDefaultCacheTTL(cacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -71,7 +76,7 @@ func (c *Client) GetCities(ctx context.Context, params *CitySearchParams, opts .
 
 func (c *Client) GetCountries(ctx context.Context, opts ...option.RequestOption) (*GenericResponse, error) {
 	var out GenericResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "countries"), nil, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "countries"), nil, &out, categories.WithDefaultCacheTTL(cacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -79,7 +84,7 @@ func (c *Client) GetCountries(ctx context.Context, opts ...option.RequestOption)
 
 func (c *Client) GetElements(ctx context.Context, opts ...option.RequestOption) (*GenericResponse, error) {
 	var out GenericResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "elements"), nil, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "elements"), nil, &out, categories.WithDefaultCacheTTL(cacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -87,7 +92,7 @@ func (c *Client) GetElements(ctx context.Context, opts ...option.RequestOption)
 
 func (c *Client) GetFixedStars(ctx context.Context, opts ...option.RequestOption) (*GenericResponse, error) {
 	var out GenericResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "fixed-stars"), nil, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "fixed-stars"), nil, &out, categories.WithDefaultCacheTTL(cacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -95,7 +100,7 @@ func (c *Client) GetFixedStars(ctx context.Context, opts ...option.RequestOption
 
 func (c *Client) GetHouseSystems(ctx context.Context, opts ...option.RequestOption) (*GenericResponse, error) {
 	var out GenericResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "house-systems"), nil, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "house-systems"), nil, &out, categories.WithDefaultCacheTTL(cacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -103,7 +108,7 @@ func (c *Client) GetHouseSystems(ctx context.Context, opts ...option.RequestOpti
 
 func (c *Client) GetHouses(ctx context.Context, params *HousesParams, opts ...option.RequestOption) (*GenericResponse, error) {
 	var out GenericResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "houses"), params, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "houses"), params, &out, categories.WithDefaultCacheTTL(cacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -111,7 +116,7 @@ func (c *Client) GetHouses(ctx context.Context, params *HousesParams, opts ...op
 
 func (c *Client) GetKeywords(ctx context.Context, params *KeywordsParams, opts ...option.RequestOption) (*GenericResponse, error) {
 	var out GenericResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "keywords"), params, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "keywords"), params, &out, categories.WithDefaultCacheTTL(cacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -119,7 +124,7 @@ func (c *Client) GetKeywords(ctx context.Context, params *KeywordsParams, opts .
 
 func (c *Client) GetLanguages(ctx context.Context, opts ...option.RequestOption) (*GenericResponse, error) {
 	var out GenericResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "languages"), nil, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "languages"), nil, &out, categories.WithDefaultCacheTTL(cacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -127,7 +132,7 @@ func (c *Client) GetLanguages(ctx context.Context, opts ...option.RequestOption)
 
 func (c *Client) GetLifeAreas(ctx context.Context, params *LifeAreasParams, opts ...option.RequestOption) (*GenericResponse, error) {
 	var out GenericResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "life-areas"), params, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "life-areas"), params, &out, categories.WithDefaultCacheTTL(cacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -135,7 +140,7 @@ func (c *Client) GetLifeAreas(ctx context.Context, params *LifeAreasParams, opts
 
 func (c *Client) GetThemes(ctx context.Context, opts ...option.RequestOption) (*GenericResponse, error) {
 	var out GenericResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "themes"), nil, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "themes"), nil, &out, categories.WithDefaultCacheTTL(cacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -143,7 +148,7 @@ func (c *Client) GetThemes(ctx context.Context, opts ...option.RequestOption) (*
 
 func (c *Client) GetZodiacTypes(ctx context.Context, opts ...option.RequestOption) (*GenericResponse, error) {
 	var out GenericResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "zodiac-types"), nil, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "zodiac-types"), nil, &out, categories.WithDefaultCacheTTL(cacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -3,10 +3,13 @@ package glossary_test
 import (
 	"context"
 	"net/http"
+	"sync/atomic"
 	"testing"
 
+	"github.com/astro-api/astroapi-go/cache"
 	"github.com/astro-api/astroapi-go/categories/glossary"
 	"github.com/astro-api/astroapi-go/internal/testutil"
+	"github.com/astro-api/astroapi-go/option"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -50,6 +53,55 @@ func TestGlossaryClient_GetHouseSystems(t *testing.T) {
 	assert.NotNil(t, result)
 }
 
+func TestGlossaryClient_GetHouseSystems_Cached(t *testing.T) {
+	var calls int32
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		testutil.JSON(w, testutil.DataEnvelope(map[string]any{"P": "Placidus"}))
+	}, option.WithCache(cache.NewLRU(10)))
+	defer cleanup()
+
+	for i := 0; i < 3; i++ {
+		result, err := client.Glossary.GetHouseSystems(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Placidus", (*result)["P"])
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// A per-request TTL of zero bypasses the cache.
+	_, err := client.Glossary.GetHouseSystems(ctx, option.WithCacheTTL(0))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	var hit string
+	_, err = client.Glossary.GetHouseSystems(ctx, option.WithHooks(option.Hooks{
+		OnCacheHit: func(e option.RequestEvent) { hit = e.Endpoint },
+		OnResponse: func(option.ResponseEvent) { t.Error("a cache hit is not a response") },
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "glossary.GetHouseSystems", hit)
+}
+
+func TestGlossaryClient_GetHouseSystems_UnsuccessfulNotCached(t *testing.T) {
+	var calls int32
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			testutil.JSON(w, map[string]any{"success": false, "data": map[string]any{}})
+			return
+		}
+		testutil.JSON(w, testutil.DataEnvelope(map[string]any{"P": "Placidus"}))
+	}, option.WithCache(cache.NewLRU(10)))
+	defer cleanup()
+
+	_, err := client.Glossary.GetHouseSystems(ctx)
+	require.NoError(t, err)
+
+	result, err := client.Glossary.GetHouseSystems(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Placidus", (*result)["P"])
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
 func TestGlossaryClient_GetLanguages(t *testing.T) {
 	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/api/v3/glossary/languages", r.URL.Path)
@@ -75,6 +127,17 @@ func TestGlossaryClient_GetCities(t *testing.T) {
 	assert.NotNil(t, result)
 }
 
+func TestGlossaryClient_GetCities_QueryEncoding(t *testing.T) {
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "country_code=GB&limit=1000000&search=St+Albans", r.URL.RawQuery)
+		testutil.JSON(w, testutil.DataEnvelope([]any{}))
+	})
+	defer cleanup()
+
+	_, err := client.Glossary.GetCities(ctx, &glossary.CitySearchParams{Search: "St Albans", CountryCode: "GB", Limit: 1000000})
+	require.NoError(t, err)
+}
+
 func TestGlossaryClient_GetActivePoints(t *testing.T) {
 	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/api/v3/glossary/active-points", r.URL.Path)
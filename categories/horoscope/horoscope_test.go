@@ -2,13 +2,18 @@ package horoscope_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	astroapi "github.com/astro-api/astroapi-go"
 	"github.com/astro-api/astroapi-go/categories/horoscope"
+	astroerrors "github.com/astro-api/astroapi-go/errors"
 	"github.com/astro-api/astroapi-go/internal/testutil"
 	"github.com/astro-api/astroapi-go/option"
+	"github.com/astro-api/astroapi-go/shared"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -50,6 +55,43 @@ func TestHoroscopeClient_GetSignDaily(t *testing.T) {
 	assert.NotNil(t, result)
 }
 
+func TestHoroscopeClient_GetSignDaily_DefaultReportOptions(t *testing.T) {
+	var gotBody map[string]any
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		testutil.JSON(w, testutil.DataEnvelope(map[string]any{"sign": "Aries"}))
+	}, option.WithDefaultReportOptions(shared.ReportOptions{Tradition: "psychological", Language: "de"}))
+	defer cleanup()
+
+	_, err := client.Horoscope.GetSignDaily(ctx, horoscope.SignHoroscopeParams{
+		Sign:    "Aries",
+		Options: &shared.ReportOptions{Language: "fr"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"tradition": "psychological", "language": "fr"}, gotBody["options"])
+}
+
+func TestHoroscopeClient_GetSignDaily_CircuitOpen(t *testing.T) {
+	var calls int32
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, option.WithCircuitBreaker(option.CircuitBreaker{MinRequests: 2, CoolOff: time.Minute}))
+	defer cleanup()
+	params := horoscope.SignHoroscopeParams{Sign: "Aries"}
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Horoscope.GetSignDaily(ctx, params)
+		assert.ErrorIs(t, err, astroerrors.ErrServer)
+	}
+
+	// The open circuit fails fast without reaching the API, so callers can
+	// fall back immediately.
+	_, err := client.Horoscope.GetSignDaily(ctx, params)
+	assert.ErrorIs(t, err, astroerrors.ErrCircuitOpen)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
 func TestHoroscopeClient_GetSignWeekly(t *testing.T) {
 	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/api/v3/horoscope/sign/weekly", r.URL.Path)
@@ -3,25 +3,25 @@ package horoscope
 import "github.com/astro-api/astroapi-go/shared"
 
 type PersonalDailyParams struct {
-	Subject shared.Subject `json:"subject" validate:"required"`
+	Subject shared.Subject `json:"subject" validate:"required,place"`
 	Options *shared.AstrologyOptions `json:"options,omitempty"`
 }
 
 type SignHoroscopeParams struct {
 	Sign    string `json:"sign" validate:"required"`
-	Date    string `json:"date,omitempty"`
+	Date    string `json:"date,omitempty" validate:"date"`
 	Options *shared.ReportOptions `json:"options,omitempty"`
 }
 
 type SignWeeklyParams struct {
 	Sign      string `json:"sign" validate:"required"`
-	WeekStart string `json:"week_start,omitempty"`
+	WeekStart string `json:"week_start,omitempty" validate:"date"`
 	Options   *shared.ReportOptions `json:"options,omitempty"`
 }
 
 type SignMonthlyParams struct {
 	Sign    string `json:"sign" validate:"required"`
-	Month   int    `json:"month,omitempty"`
+	Month   int    `json:"month,omitempty" validate:"min=1,max=12"`
 	Year    int    `json:"year,omitempty"`
 	Options *shared.ReportOptions `json:"options,omitempty"`
 }
@@ -33,7 +33,7 @@ type SignYearlyParams struct {
 }
 
 type PersonalTextParams struct {
-	Subject shared.Subject `json:"subject" validate:"required"`
+	Subject shared.Subject `json:"subject" validate:"required,place"`
 	Format  string         `json:"format,omitempty"`
 	Options *shared.ReportOptions `json:"options,omitempty"`
 }
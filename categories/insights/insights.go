@@ -17,23 +17,23 @@ type GenericResponse map[string]any
 // ---- Common param types ----
 
 type SingleSubjectParams struct {
-	Subject shared.Subject `json:"subject" validate:"required"`
+	Subject shared.Subject `json:"subject" validate:"required,place"`
 	Options *shared.AstrologyOptions `json:"options,omitempty"`
 }
 
 type TwoSubjectParams struct {
-	Subject1 shared.Subject `json:"subject1" validate:"required"`
-	Subject2 shared.Subject `json:"subject2" validate:"required"`
+	Subject1 shared.Subject `json:"subject1" validate:"required,place"`
+	Subject2 shared.Subject `json:"subject2" validate:"required,place"`
 	Options  *shared.AstrologyOptions `json:"options,omitempty"`
 }
 
 type MultiSubjectParams struct {
-	Subjects []shared.Subject `json:"subjects" validate:"required"`
+	Subjects []shared.Subject `json:"subjects" validate:"required,place"`
 	Options  *shared.AstrologyOptions `json:"options,omitempty"`
 }
 
 type TimingParams struct {
-	Subject   shared.Subject    `json:"subject" validate:"required"`
+	Subject   shared.Subject    `json:"subject" validate:"required,place"`
 	DateRange *shared.DateRange `json:"date_range,omitempty"`
 }
 
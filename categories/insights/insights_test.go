@@ -44,6 +44,24 @@ func TestInsightsClient_Relationship_GetCompatibility(t *testing.T) {
 	assert.NotNil(t, result)
 }
 
+func TestInsightsClient_Relationship_HookEndpoint(t *testing.T) {
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		testutil.JSON(w, testutil.DataEnvelope(map[string]any{}))
+	})
+	defer cleanup()
+
+	var endpoints []string
+	hooks := option.WithHooks(option.Hooks{OnRequestStart: func(e option.RequestEvent) {
+		endpoints = append(endpoints, e.Endpoint)
+	}})
+	params := insights.TwoSubjectParams{Subject1: testutil.DefaultSubject(), Subject2: testutil.DefaultSubject2()}
+	_, err := client.Insights.Relationship.GetCompatibility(ctx, params, hooks)
+	require.NoError(t, err)
+	_, err = client.Insights.Discover(ctx, hooks)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"insights.relationship.GetCompatibility", "insights.Discover"}, endpoints)
+}
+
 func TestInsightsClient_Relationship_ValidationError(t *testing.T) {
 	client := astroapi.NewClient(option.WithAPIKey("test-key"), option.WithMaxRetries(0))
 	_, err := client.Insights.Relationship.GetCompatibility(ctx, insights.TwoSubjectParams{})
@@ -0,0 +1,211 @@
+package categories
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/astro-api/astroapi-go/internal/requestconfig"
+)
+
+// maxLoggedBody is the number of body bytes logged at debug level.
+const maxLoggedBody = 64 << 10
+
+const redacted = "[REDACTED]"
+
+// secretHeaders are never logged.
+var secretHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// enableLogging adds the hooks and, at debug level, the body-logging
+// middleware for cfg.Logger to cfg, which must be a per-request clone.
+func enableLogging(ctx context.Context, cfg *requestconfig.RequestConfig) {
+	cfg.Hooks = chainHooks(cfg.Hooks, logHooks(cfg.Logger))
+	if cfg.Logger.Enabled(ctx, slog.LevelDebug) {
+		// Appended last, so it sees the request as it is handed to the
+		// transport stack.
+		cfg.Middlewares = append(cfg.Middlewares, logBodies(cfg.Logger, cfg.LogRedactPII))
+	}
+}
+
+// logHooks logs finished calls at info level, failed calls at error level,
+// retries at warn level and every attempt at debug level.
+func logHooks(logger *slog.Logger) *requestconfig.Hooks {
+	return &requestconfig.Hooks{
+		OnAttempt: func(e requestconfig.AttemptEvent) {
+			attrs := append(eventAttrs(e.RequestEvent),
+				slog.Int("attempt", e.Attempt),
+				slog.Int("status", e.StatusCode),
+				slog.Duration("latency", e.Latency),
+			)
+			if e.Err != nil {
+				attrs = append(attrs, slog.String("error", e.Err.Error()))
+			}
+			if e.Attempt > 1 {
+				logger.LogAttrs(e.Context, slog.LevelWarn, "astroapi retry", append(attrs, slog.Duration("delay", e.Delay))...)
+				return
+			}
+			logger.LogAttrs(e.Context, slog.LevelDebug, "astroapi attempt", attrs...)
+		},
+		OnResponse: func(e requestconfig.ResponseEvent) {
+			logger.LogAttrs(e.Context, slog.LevelInfo, "astroapi request", append(eventAttrs(e.RequestEvent),
+				slog.Int("status", e.StatusCode),
+				slog.Int("attempts", e.Attempts),
+				slog.Duration("latency", e.Latency),
+			)...)
+		},
+		OnError: func(e requestconfig.ErrorEvent) {
+			logger.LogAttrs(e.Context, slog.LevelError, "astroapi request failed", append(eventAttrs(e.RequestEvent),
+				slog.Int("status", e.StatusCode),
+				slog.Int("attempts", e.Attempts),
+				slog.Duration("latency", e.Latency),
+				slog.String("error", e.Err.Error()),
+			)...)
+		},
+		OnCacheHit: func(e requestconfig.RequestEvent) {
+			logger.LogAttrs(e.Context, slog.LevelInfo, "astroapi cache hit", eventAttrs(e)...)
+		},
+	}
+}
+
+func eventAttrs(e requestconfig.RequestEvent) []slog.Attr {
+	return []slog.Attr{
+		slog.String("endpoint", e.Endpoint),
+		slog.String("method", e.Method),
+		slog.String("url", e.URL),
+	}
+}
+
+// chainHooks returns hooks that call the hooks of a and then those of b.
+func chainHooks(a, b *requestconfig.Hooks) *requestconfig.Hooks {
+	if a == nil {
+		return b
+	}
+	return &requestconfig.Hooks{
+		OnRequestStart: chain(a.OnRequestStart, b.OnRequestStart),
+		OnAttempt:      chain(a.OnAttempt, b.OnAttempt),
+		OnResponse:     chain(a.OnResponse, b.OnResponse),
+		OnError:        chain(a.OnError, b.OnError),
+		OnCacheHit:     chain(a.OnCacheHit, b.OnCacheHit),
+	}
+}
+
+func chain[E any](a, b func(E)) func(E) {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	}
+	return func(e E) {
+		a(e)
+		b(e)
+	}
+}
+
+// logBodies returns a middleware that logs request and response headers and
+// bodies at debug level. Secret headers are always redacted; with redactPII,
+// personal data of subjects is masked as well (see redactBody).
+func logBodies(logger *slog.Logger, redactPII bool) requestconfig.Middleware {
+	return func(req *http.Request, next requestconfig.MiddlewareNext) (*http.Response, error) {
+		ctx := req.Context()
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("url", req.URL.String()),
+			slog.Any("header", redactHeaders(req.Header)),
+		}
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				data, _ := io.ReadAll(body)
+				attrs = append(attrs, slog.String("body", redactBody(data, redactPII)))
+			}
+		}
+		logger.LogAttrs(ctx, slog.LevelDebug, "astroapi request body", attrs...)
+
+		resp, err := next(req)
+		if err != nil {
+			return resp, err
+		}
+		data, readErr := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		attrs = []slog.Attr{
+			slog.Int("status", resp.StatusCode),
+			slog.Any("header", redactHeaders(resp.Header)),
+			slog.String("body", redactBody(data, redactPII)),
+		}
+		if readErr != nil {
+			// Hand the read error to the caller once the logged part is consumed.
+			resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(data), errReader{readErr}))
+			attrs = append(attrs, slog.String("error", readErr.Error()))
+		}
+		logger.LogAttrs(ctx, slog.LevelDebug, "astroapi response body", attrs...)
+		return resp, nil
+	}
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+// redactHeaders returns a copy of h with secret headers masked.
+func redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	for _, name := range secretHeaders {
+		if out.Get(name) != "" {
+			out.Set(name, redacted)
+		}
+	}
+	return out
+}
+
+// redactBody returns body as text, truncated to maxLoggedBody. With pii, the
+// name, email and notes of every JSON object that has a "birth_data" key (a
+// shared.Subject) are masked, as are the coordinates of its birth data.
+func redactBody(body []byte, pii bool) string {
+	if pii && len(body) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err == nil {
+			redactSubjects(v)
+			if masked, err := json.Marshal(v); err == nil {
+				body = masked
+			}
+		} else {
+			// Never log what could not be checked.
+			return redacted
+		}
+	}
+	if len(body) > maxLoggedBody {
+		return string(body[:maxLoggedBody]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+func redactSubjects(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		if birth, ok := v["birth_data"].(map[string]any); ok {
+			for _, k := range []string{"name", "email", "notes"} {
+				if _, ok := v[k]; ok {
+					v[k] = redacted
+				}
+			}
+			for _, k := range []string{"latitude", "longitude"} {
+				if _, ok := birth[k]; ok {
+					birth[k] = redacted
+				}
+			}
+		}
+		for _, child := range v {
+			redactSubjects(child)
+		}
+	case []any:
+		for _, child := range v {
+			redactSubjects(child)
+		}
+	}
+}
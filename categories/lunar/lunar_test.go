@@ -25,7 +25,7 @@ func TestLunarClient_GetPhase(t *testing.T) {
 	defer cleanup()
 
 	result, err := client.Lunar.GetPhase(ctx, lunar.PhasesParams{
-		DateRange: shared.DateRange{Start: "2024-01-01", End: "2024-01-31"},
+		DateRange: shared.DateRange{Start: shared.NewDate(2024, 1, 1), End: shared.NewDate(2024, 1, 31)},
 	})
 	require.NoError(t, err)
 	assert.NotNil(t, result)
@@ -37,6 +37,15 @@ func TestLunarClient_GetPhase_ValidationError(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestLunarClient_GetPhase_MalformedDateRange(t *testing.T) {
+	client := astroapi.NewClient(option.WithAPIKey("test-key"), option.WithMaxRetries(0))
+	_, err := client.Lunar.GetPhase(ctx, lunar.PhasesParams{
+		DateRange: shared.DateRange{Start: shared.NewDate(2024, 2, 30), End: shared.NewDate(2024, 3, 31)},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "date_range.start 2024-02-30 is not a calendar date")
+}
+
 func TestLunarClient_GetCalendar(t *testing.T) {
 	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/api/v3/lunar/calendar/2024", r.URL.Path)
@@ -58,7 +67,7 @@ func TestLunarClient_GetVoidOfCourse(t *testing.T) {
 	defer cleanup()
 
 	result, err := client.Lunar.GetVoidOfCourse(ctx, lunar.VoidOfCourseParams{
-		DateRange: shared.DateRange{Start: "2024-01-01", End: "2024-01-07"},
+		DateRange: shared.DateRange{Start: shared.NewDate(2024, 1, 1), End: shared.NewDate(2024, 1, 7)},
 	})
 	require.NoError(t, err)
 	assert.NotNil(t, result)
@@ -72,7 +81,7 @@ func TestLunarClient_GetEvents(t *testing.T) {
 	defer cleanup()
 
 	result, err := client.Lunar.GetEvents(ctx, lunar.EventsParams{
-		DateRange: shared.DateRange{Start: "2024-01-01", End: "2024-01-31"},
+		DateRange: shared.DateRange{Start: shared.NewDate(2024, 1, 1), End: shared.NewDate(2024, 1, 31)},
 	})
 	require.NoError(t, err)
 	assert.NotNil(t, result)
@@ -97,7 +106,7 @@ func TestLunarClient_Integration(t *testing.T) {
 
 	t.Run("GetPhase", func(t *testing.T) {
 		result, err := client.Lunar.GetPhase(ctx, lunar.PhasesParams{
-			DateRange: shared.DateRange{Start: "2024-01-01", End: "2024-01-31"},
+			DateRange: shared.DateRange{Start: shared.NewDate(2024, 1, 1), End: shared.NewDate(2024, 1, 31)},
 		})
 		require.NoError(t, err)
 		assert.NotNil(t, result)
@@ -111,7 +120,7 @@ func TestLunarClient_Integration(t *testing.T) {
 
 	t.Run("GetVoidOfCourse", func(t *testing.T) {
 		result, err := client.Lunar.GetVoidOfCourse(ctx, lunar.VoidOfCourseParams{
-			DateRange: shared.DateRange{Start: "2024-01-01", End: "2024-01-14"},
+			DateRange: shared.DateRange{Start: shared.NewDate(2024, 1, 1), End: shared.NewDate(2024, 1, 14)},
 		})
 		require.NoError(t, err)
 		assert.NotNil(t, result)
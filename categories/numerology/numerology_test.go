@@ -9,6 +9,7 @@ import (
 	"github.com/astro-api/astroapi-go/categories/numerology"
 	"github.com/astro-api/astroapi-go/internal/testutil"
 	"github.com/astro-api/astroapi-go/option"
+	"github.com/astro-api/astroapi-go/shared"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -36,6 +37,18 @@ func TestNumerologyClient_GetReport_ValidationError(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestNumerologyClient_GetReport_DateOnly(t *testing.T) {
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		testutil.JSON(w, testutil.DataEnvelope(map[string]any{"life_path": 7}))
+	})
+	defer cleanup()
+
+	_, err := client.Numerology.GetReport(ctx, numerology.SingleSubjectParams{
+		Subject: shared.Subject{BirthData: shared.BirthData{Year: 1815, Month: shared.F(12), Day: shared.F(10)}},
+	})
+	require.NoError(t, err)
+}
+
 func TestNumerologyClient_GetComprehensiveReport(t *testing.T) {
 	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/api/v3/numerology/comprehensive", r.URL.Path)
@@ -12,25 +12,25 @@ import (
 const apiPrefix = "api/v3/svg"
 
 type NatalChartSVGParams struct {
-	Subject shared.Subject `json:"subject" validate:"required"`
+	Subject shared.Subject `json:"subject" validate:"required,place"`
 	Theme   string         `json:"theme,omitempty"`
 	Options *shared.AstrologyOptions `json:"options,omitempty"`
 }
 
 type SynastryChartSVGParams struct {
-	Subject1 shared.Subject `json:"subject1" validate:"required"`
-	Subject2 shared.Subject `json:"subject2" validate:"required"`
+	Subject1 shared.Subject `json:"subject1" validate:"required,place"`
+	Subject2 shared.Subject `json:"subject2" validate:"required,place"`
 	Theme    string         `json:"theme,omitempty"`
 }
 
 type CompositeChartSVGParams struct {
-	Subject1 shared.Subject `json:"subject1" validate:"required"`
-	Subject2 shared.Subject `json:"subject2" validate:"required"`
+	Subject1 shared.Subject `json:"subject1" validate:"required,place"`
+	Subject2 shared.Subject `json:"subject2" validate:"required,place"`
 	Theme    string         `json:"theme,omitempty"`
 }
 
 type TransitChartSVGParams struct {
-	NatalSubject    shared.Subject          `json:"natal_subject" validate:"required"`
+	NatalSubject    shared.Subject          `json:"natal_subject" validate:"required,place"`
 	TransitDatetime shared.DateTimeLocation `json:"transit_datetime" validate:"required"`
 	Theme           string                  `json:"theme,omitempty"`
 }
@@ -2,11 +2,15 @@ package svg_test
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	astroapi "github.com/astro-api/astroapi-go"
 	"github.com/astro-api/astroapi-go/categories/svg"
+	astroerrors "github.com/astro-api/astroapi-go/errors"
 	"github.com/astro-api/astroapi-go/internal/testutil"
 	"github.com/astro-api/astroapi-go/option"
 	"github.com/stretchr/testify/assert"
@@ -34,6 +38,60 @@ func TestSVGClient_GetNatalChart(t *testing.T) {
 	assert.Contains(t, result, "<svg")
 }
 
+// stall blocks until the client abandons r. The body is read first so that
+// the server notices the closed connection.
+func stall(r *http.Request) {
+	_, _ = io.Copy(io.Discard, r.Body)
+	<-r.Context().Done()
+}
+
+func TestSVGClient_GetNatalChart_AttemptTimeout(t *testing.T) {
+	var calls int32
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// The first attempt stalls until the client gives up on it.
+			stall(r)
+			return
+		}
+		w.Header().Set("Content-Type", "image/svg+xml")
+		_, _ = w.Write([]byte(fakeSVG))
+	}, option.WithMaxRetries(1), option.WithRetryDelay(time.Millisecond), option.WithAttemptTimeout(50*time.Millisecond))
+	defer cleanup()
+
+	result, err := client.SVG.GetNatalChart(ctx, svg.NatalChartSVGParams{Subject: testutil.DefaultSubject()})
+	require.NoError(t, err)
+	assert.Contains(t, result, "<svg")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestSVGClient_GetNatalChart_Deadlines(t *testing.T) {
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		stall(r)
+	}, option.WithMaxRetries(1), option.WithRetryDelay(time.Millisecond))
+	defer cleanup()
+	params := svg.NatalChartSVGParams{Subject: testutil.DefaultSubject()}
+
+	_, err := client.SVG.GetNatalChart(ctx, params, option.WithAttemptTimeout(20*time.Millisecond))
+	assert.ErrorIs(t, err, astroerrors.ErrAttemptTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, astroerrors.ErrRequestTimeout)
+
+	_, err = client.SVG.GetNatalChart(ctx, params, option.WithRequestTimeout(50*time.Millisecond))
+	assert.ErrorIs(t, err, astroerrors.ErrRequestTimeout)
+	assert.NotErrorIs(t, err, astroerrors.ErrAttemptTimeout)
+	assert.Contains(t, err.Error(), "request timed out after 50ms")
+
+	// A shorter deadline on the caller's context wins.
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	_, err = client.SVG.GetNatalChart(short, params, option.WithRequestTimeout(time.Minute), option.WithAttemptTimeout(time.Minute))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, astroerrors.ErrRequestTimeout)
+	assert.NotErrorIs(t, err, astroerrors.ErrAttemptTimeout)
+	assert.Less(t, time.Since(started), time.Second)
+}
+
 func TestSVGClient_GetNatalChart_ValidationError(t *testing.T) {
 	client := astroapi.NewClient(option.WithAPIKey("test-key"), option.WithMaxRetries(0))
 	_, err := client.SVG.GetNatalChart(ctx, svg.NatalChartSVGParams{})
@@ -3,6 +3,7 @@ package tarot
 
 import (
 	"context"
+	"time"
 
 	"github.com/astro-api/astroapi-go/categories"
 	"github.com/astro-api/astroapi-go/option"
@@ -11,10 +12,13 @@ import (
 
 const apiPrefix = "api/v3/tarot"
 
+// glossaryCacheTTL is how long the card and spread glossaries are cached when a cache is configured.
+const glossaryCacheTTL = 12 * time.Hour
+
 type GenericResponse map[string]any
 
 type DrawCardsParams struct {
-	Count       int    `json:"count" validate:"required"`
+	Count       int    `json:"count" validate:"required,min=1,max=78"`
 	SpreadType  string `json:"spread_type,omitempty"`
 	Tradition   string `json:"tradition,omitempty"`
 }
@@ -48,7 +52,7 @@ type GlossaryParams struct {
 }
 
 type DailyCardParams struct {
-	Date      string `json:"date,omitempty" url:"date,omitempty"`
+	Date      string `json:"date,omitempty" url:"date,omitempty" validate:"date"`
 	Tradition string `json:"tradition,omitempty" url:"tradition,omitempty"`
 }
 
@@ -88,7 +92,7 @@ func (c *Client) GetSpread(ctx context.Context, params SpreadParams, opts ...opt
 
 func (c *Client) GetCardsGlossary(ctx context.Context, params *GlossaryParams, opts ...option.RequestOption) (*GenericResponse, error) {
 	var out GenericResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "glossary", "cards"), params, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "glossary", "cards"), params, &out, categories.WithDefaultCacheTTL(glossaryCacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -96,7 +100,7 @@ func (c *Client) GetCardsGlossary(ctx context.Context, params *GlossaryParams, o
 
 func (c *Client) GetSpreadsGlossary(ctx context.Context, opts ...option.RequestOption) (*GenericResponse, error) {
 	var out GenericResponse
-	if err := c.Get(ctx, c.BuildURL(apiPrefix, "glossary", "spreads"), nil, &out, opts...); err != nil {
+	if err := c.Get(ctx, c.BuildURL(apiPrefix, "glossary", "spreads"), nil, &out, categories.WithDefaultCacheTTL(glossaryCacheTTL, opts)...); err != nil {
 		return nil, err
 	}
 	return &out, nil
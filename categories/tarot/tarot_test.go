@@ -3,10 +3,13 @@ package tarot_test
 import (
 	"context"
 	"net/http"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	astroapi "github.com/astro-api/astroapi-go"
 	"github.com/astro-api/astroapi-go/categories/tarot"
+	astroerrors "github.com/astro-api/astroapi-go/errors"
 	"github.com/astro-api/astroapi-go/internal/testutil"
 	"github.com/astro-api/astroapi-go/option"
 	"github.com/stretchr/testify/assert"
@@ -34,6 +37,13 @@ func TestTarotClient_GetDraw_ValidationError(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestTarotClient_GetDraw_CountOutOfRange(t *testing.T) {
+	client := astroapi.NewClient(option.WithAPIKey("test-key"), option.WithMaxRetries(0))
+	_, err := client.Tarot.GetDraw(ctx, tarot.DrawCardsParams{Count: 500})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "count must be at most 78")
+}
+
 func TestTarotClient_GetCard(t *testing.T) {
 	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/api/v3/tarot/cards/the-fool", r.URL.Path)
@@ -47,6 +57,25 @@ func TestTarotClient_GetCard(t *testing.T) {
 	assert.NotNil(t, result)
 }
 
+func TestTarotClient_GetCard_OneCircuitForAllCards(t *testing.T) {
+	var calls int32
+	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, option.WithMaxRetries(0), option.WithCircuitBreaker(option.CircuitBreaker{MinRequests: 2, CoolOff: time.Minute}))
+	defer cleanup()
+
+	for _, card := range []string{"the-fool", "the-magician"} {
+		_, err := client.Tarot.GetCard(ctx, card)
+		assert.ErrorIs(t, err, astroerrors.ErrServer)
+	}
+
+	// Failures for different cards opened the same circuit.
+	_, err := client.Tarot.GetCard(ctx, "the-tower")
+	assert.ErrorIs(t, err, astroerrors.ErrCircuitOpen)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
 func TestTarotClient_GetDailyCard(t *testing.T) {
 	client, cleanup := testutil.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/api/v3/tarot/daily-card", r.URL.Path)
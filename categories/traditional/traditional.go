@@ -14,17 +14,17 @@ const apiPrefix = "api/v3/traditional"
 type GenericResponse map[string]any
 
 type AnalysisParams struct {
-	Subject shared.Subject `json:"subject" validate:"required"`
+	Subject shared.Subject `json:"subject" validate:"required,place"`
 	Options *shared.AstrologyOptions `json:"options,omitempty"`
 }
 
 type ProfectionParams struct {
-	Subject    shared.Subject `json:"subject" validate:"required"`
-	TargetDate string         `json:"target_date,omitempty"`
+	Subject    shared.Subject `json:"subject" validate:"required,place"`
+	TargetDate string         `json:"target_date,omitempty" validate:"date"`
 }
 
 type ProfectionTimelineParams struct {
-	Subject    shared.Subject `json:"subject" validate:"required"`
+	Subject    shared.Subject `json:"subject" validate:"required,place"`
 	StartAge   int            `json:"start_age,omitempty"`
 	EndAge     int            `json:"end_age,omitempty"`
 }
@@ -1,6 +1,7 @@
 package astroapi
 
 import (
+	"errors"
 	"os"
 
 	"github.com/astro-api/astroapi-go/categories"
@@ -20,6 +21,7 @@ import (
 	"github.com/astro-api/astroapi-go/categories/svg"
 	"github.com/astro-api/astroapi-go/categories/tarot"
 	"github.com/astro-api/astroapi-go/categories/traditional"
+	"github.com/astro-api/astroapi-go/internal/profile"
 	"github.com/astro-api/astroapi-go/internal/requestconfig"
 	"github.com/astro-api/astroapi-go/option"
 )
@@ -44,37 +46,79 @@ type AstrologyClient struct {
 	SVG              *svg.Client
 	Enhanced         *enhanced.Client
 
-	cfg *requestconfig.RequestConfig
+	cfg  *requestconfig.RequestConfig
+	base *categories.BaseCategoryClient
 }
 
-// NewClient creates a new AstrologyClient. Configuration defaults are applied
-// first, then options in order, then environment variable fallbacks.
+// NewClient creates a new AstrologyClient. Settings are resolved from
+// lowest to highest precedence: built-in defaults, the config-file profile
+// selected with option.WithProfile or ASTROLOGY_API_PROFILE, the
+// ASTROLOGY_API_KEY and ASTROLOGY_API_BASE_URL environment variables, and
+// finally the explicit options.
 //
 //	client := astroapi.NewClient(
 //	    option.WithAPIKey("your-api-key"),
 //	)
 func NewClient(opts ...option.RequestOption) *AstrologyClient {
-	cfg := requestconfig.NewDefault()
 	rawOpts := make([]func(*requestconfig.RequestConfig), len(opts))
 	for i, o := range opts {
 		rawOpts[i] = o
 	}
-	cfg.Apply(rawOpts)
 
-	// Environment variable fallbacks.
-	if cfg.APIKey == "" {
-		cfg.APIKey = os.Getenv("ASTROLOGY_API_KEY")
-	}
-	if cfg.BaseURL == "" || cfg.BaseURL == requestconfig.DefaultBaseURL {
-		if envURL := os.Getenv("ASTROLOGY_API_BASE_URL"); envURL != "" {
-			cfg.BaseURL = envURL
+	cfg := requestconfig.NewDefault()
+	if name := profileName(rawOpts); name != "" {
+		if p, err := profile.Load(name); err != nil {
+			cfg.Err = err
+		} else {
+			p.Apply(cfg)
 		}
 	}
 
-	base := &categories.BaseCategoryClient{Config: cfg}
+	// Environment variables override the profile.
+	if key := os.Getenv("ASTROLOGY_API_KEY"); key != "" {
+		cfg.APIKey = key
+	}
+	if envURL := os.Getenv("ASTROLOGY_API_BASE_URL"); envURL != "" {
+		cfg.BaseURL = envURL
+	}
+
+	cfg.Apply(rawOpts)
+	rejectPerRequestOptions(cfg)
+
+	return newClient(categories.NewBaseCategoryClient(cfg))
+}
+
+// WithOptions returns a new client whose config is this client's config with
+// opts applied on top, e.g. a different API key, header or timeout for one
+// tenant. The new client shares this client's connection pool, response
+// cache and rate limiter unless opts replace them.
+//
+//	tenant := client.WithOptions(option.WithAPIKey(tenantKey), option.WithHeader("Accept-Language", "de"))
+func (c *AstrologyClient) WithOptions(opts ...option.RequestOption) *AstrologyClient {
+	cfg := c.cfg.Clone()
+	rawOpts := make([]func(*requestconfig.RequestConfig), len(opts))
+	for i, o := range opts {
+		rawOpts[i] = o
+	}
+	cfg.Apply(rawOpts)
+	rejectPerRequestOptions(cfg)
+	return newClient(c.base.Derive(cfg))
+}
+
+// rejectPerRequestOptions makes every call of a client fail if its config
+// holds an option that only makes sense for a single call. A client-wide
+// idempotency key would let the API deduplicate unrelated calls onto the
+// first response.
+func rejectPerRequestOptions(cfg *requestconfig.RequestConfig) {
+	if cfg.IdempotencyKey != "" && cfg.Err == nil {
+		cfg.Err = errors.New("option.WithIdempotencyKey must be passed per request, not to NewClient or WithOptions")
+	}
+}
 
+func newClient(base *categories.BaseCategoryClient) *AstrologyClient {
 	return &AstrologyClient{
-		cfg:              cfg,
+		cfg:              base.Config,
+		base:             base,
 		Data:             data.NewClient(base),
 		Charts:           charts.NewClient(base),
 		Horoscope:        horoscope.NewClient(base),
@@ -93,3 +137,14 @@ func NewClient(opts ...option.RequestOption) *AstrologyClient {
 		Enhanced:         enhanced.NewClient(base),
 	}
 }
+
+// profileName returns the profile selected by option.WithProfile, falling
+// back to ASTROLOGY_API_PROFILE.
+func profileName(opts []func(*requestconfig.RequestConfig)) string {
+	probe := requestconfig.NewDefault()
+	probe.Apply(opts)
+	if probe.Profile != "" {
+		return probe.Profile
+	}
+	return os.Getenv("ASTROLOGY_API_PROFILE")
+}
@@ -1,10 +1,24 @@
 package astroapi_test
 
 import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	astroapi "github.com/astro-api/astroapi-go"
+	"github.com/astro-api/astroapi-go/cache"
+	"github.com/astro-api/astroapi-go/categories/charts"
+	"github.com/astro-api/astroapi-go/credentials"
+	"github.com/astro-api/astroapi-go/internal/testutil"
 	"github.com/astro-api/astroapi-go/option"
+	"github.com/astro-api/astroapi-go/shared"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -45,3 +59,285 @@ func TestNewClient_NoKey(t *testing.T) {
 	client := astroapi.NewClient()
 	require.NotNil(t, client)
 }
+
+func TestNewClient_WithRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer srv.Close()
+
+	client := astroapi.NewClient(
+		option.WithAPIKey("test-key"),
+		option.WithBaseURL(srv.URL),
+		option.WithRateLimit(20, 1),
+	)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := client.Data.GetNow(context.Background())
+		require.NoError(t, err)
+	}
+	// The first request uses the burst token; the next two wait ~50ms each.
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func TestNewClient_ReusesConnections(t *testing.T) {
+	var newConns, calls int32
+	var lastAuth atomic.Value
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		lastAuth.Store(r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&newConns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	client := astroapi.NewClient(
+		option.WithAPIKey("test-key"),
+		option.WithBaseURL(srv.URL),
+	)
+
+	for i := 0; i < 5; i++ {
+		_, err := client.Data.GetNow(context.Background())
+		require.NoError(t, err)
+	}
+	// Per-request transport overrides build a new stack over the same pool.
+	_, err := client.Data.GetNow(context.Background(), option.WithAPIKey("other-key"), option.WithMaxRetries(0))
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(6), atomic.LoadInt32(&calls))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&newConns))
+	assert.Equal(t, "Bearer other-key", lastAuth.Load())
+}
+
+func TestAstrologyClient_WithOptions(t *testing.T) {
+	var calls, newConns int32
+	var lastAuth, lastLang atomic.Value
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		lastAuth.Store(r.Header.Get("Authorization"))
+		lastLang.Store(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&newConns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	parent := astroapi.NewClient(
+		option.WithAPIKey("parent-key"),
+		option.WithBaseURL(srv.URL),
+		option.WithCache(cache.NewLRU(10)),
+	)
+	child := parent.WithOptions(option.WithAPIKey("tenant-key"), option.WithHeader("Accept-Language", "de"))
+
+	_, err := parent.Data.GetNow(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer parent-key", lastAuth.Load())
+	assert.Equal(t, "", lastLang.Load())
+
+	_, err = child.Data.GetNow(context.Background(), option.WithCacheTTL(0))
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer tenant-key", lastAuth.Load())
+	assert.Equal(t, "de", lastLang.Load())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&newConns), "the child reuses the parent's pool")
+
+	// The child shares the parent's cache, but not its entries: it calls
+	// with another key and language.
+	_, err = parent.Glossary.GetCountries(context.Background())
+	require.NoError(t, err)
+	_, err = child.Glossary.GetCountries(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+	assert.Equal(t, "Bearer tenant-key", lastAuth.Load())
+	_, err = child.Glossary.GetCountries(context.Background())
+	require.NoError(t, err)
+	_, err = parent.Glossary.GetCountries(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+
+	// The parent is unchanged.
+	_, err = parent.Data.GetNow(context.Background(), option.WithCacheTTL(0))
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer parent-key", lastAuth.Load())
+}
+
+func TestNewClient_RejectsClientWideIdempotencyKey(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer srv.Close()
+
+	client := astroapi.NewClient(option.WithAPIKey("k"), option.WithBaseURL(srv.URL))
+	for _, c := range []*astroapi.AstrologyClient{
+		astroapi.NewClient(option.WithAPIKey("k"), option.WithBaseURL(srv.URL), option.WithIdempotencyKey("shared")),
+		client.WithOptions(option.WithIdempotencyKey("shared")),
+	} {
+		_, err := c.Data.GetNow(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "WithIdempotencyKey")
+	}
+	assert.Zero(t, atomic.LoadInt32(&calls))
+
+	// Per request it is fine.
+	_, err := client.Data.GetNow(context.Background(), option.WithIdempotencyKey("once"))
+	require.NoError(t, err)
+}
+
+func TestNewClient_WithCredentials(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(path, []byte("week-1\n"), 0o600))
+	client := astroapi.NewClient(
+		option.WithBaseURL(srv.URL),
+		option.WithCredentials(credentials.File(path)),
+	)
+
+	_, err := client.Data.GetNow(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer week-1", gotAuth)
+
+	// The rotated key is picked up without rebuilding the client.
+	require.NoError(t, os.WriteFile(path, []byte("week-2-key\n"), 0o600))
+	_, err = client.Data.GetNow(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer week-2-key", gotAuth)
+
+	// A per-request key replaces the provider.
+	_, err = client.Data.GetNow(context.Background(), option.WithAPIKey("override"))
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer override", gotAuth)
+}
+
+func TestNewClient_WithBaseURLs(t *testing.T) {
+	var calls int32
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		assert.Equal(t, "/api/v3/data/now", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer fallback.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close() // connections to the primary are refused
+
+	client := astroapi.NewClient(
+		option.WithAPIKey("test-key"),
+		option.WithBaseURLs(down.URL, fallback.URL),
+		option.WithRetryDelay(time.Millisecond),
+	)
+	_, err := client.Data.GetNow(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	_, err = astroapi.NewClient(option.WithBaseURLs("not a url")).Data.GetNow(context.Background())
+	assert.ErrorContains(t, err, "WithBaseURLs")
+}
+
+// writeProfiles points ASTROLOGY_API_CONFIG at a config file with a
+// "staging" profile for baseURL.
+func writeProfiles(t *testing.T, baseURL string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	config := `{"profiles": {"staging": {
+		"api_key": "profile-key",
+		"base_url": "` + baseURL + `",
+		"max_retries": 0,
+		"request_timeout": "5s",
+		"headers": {"X-Team": "charts"},
+		"chart_options": {"house_system": "K", "language": "de"}
+	}}}`
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+	t.Setenv("ASTROLOGY_API_CONFIG", path)
+	t.Setenv("ASTROLOGY_API_KEY", "")
+	t.Setenv("ASTROLOGY_API_BASE_URL", "")
+	t.Setenv("ASTROLOGY_API_PROFILE", "")
+}
+
+func TestNewClient_Profile(t *testing.T) {
+	var gotAuth, gotTeam string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotTeam = r.Header.Get("X-Team")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer srv.Close()
+	writeProfiles(t, srv.URL)
+
+	client := astroapi.NewClient(option.WithProfile("staging"))
+	_, err := client.Charts.GetNatal(context.Background(), charts.NatalChartParams{Subject: testutil.DefaultSubject()})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Bearer profile-key", gotAuth)
+	assert.Equal(t, "charts", gotTeam)
+	assert.Equal(t, map[string]any{"house_system": "K", "language": "de"}, gotBody["options"])
+
+	// Explicit chart options win over the profile's.
+	_, err = client.Charts.GetNatal(context.Background(), charts.NatalChartParams{
+		Subject: testutil.DefaultSubject(),
+		Options: &shared.AstrologyOptions{HouseSystem: "P"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"house_system": "P", "language": "de"}, gotBody["options"])
+}
+
+func TestNewClient_ProfilePrecedence(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer srv.Close()
+	writeProfiles(t, srv.URL)
+	t.Setenv("ASTROLOGY_API_PROFILE", "staging")
+
+	// The profile is selected from the environment.
+	_, err := astroapi.NewClient().Data.GetNow(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer profile-key", gotAuth)
+
+	// Environment variables override the profile.
+	t.Setenv("ASTROLOGY_API_KEY", "env-key")
+	_, err = astroapi.NewClient().Data.GetNow(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer env-key", gotAuth)
+
+	// Explicit options override both.
+	_, err = astroapi.NewClient(option.WithAPIKey("option-key")).Data.GetNow(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer option-key", gotAuth)
+}
+
+func TestNewClient_ProfileNotFound(t *testing.T) {
+	writeProfiles(t, "http://127.0.0.1:0")
+
+	client := astroapi.NewClient(option.WithProfile("production"))
+	_, err := client.Data.GetNow(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `profile "production"`)
+}
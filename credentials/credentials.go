@@ -0,0 +1,138 @@
+// Package credentials provides API key providers for the Astrology API SDK.
+//
+// A Provider is asked for a key on every request, so keys can change while
+// the process runs. Set one with option.WithCredentials:
+//
+//	client := astroapi.NewClient(
+//	    option.WithCredentials(credentials.File("/var/run/secrets/astroapi/key")),
+//	)
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Provider supplies the API key for a request.
+// Implementations must be safe for concurrent use.
+type Provider interface {
+	// APIKey returns the key to send with a request made with ctx.
+	APIKey(ctx context.Context) (string, error)
+}
+
+// Rejecter is implemented by providers that react to the API rejecting a
+// key with 401 Unauthorized or 402 Payment Required, or with a 429 Too Many
+// Requests that reports an exhausted quota: X-RateLimit-Remaining is 0 and
+// X-RateLimit-Reset is at least ten minutes away.
+type Rejecter interface {
+	// Reject reports that key was rejected with statusCode.
+	Reject(key string, statusCode int)
+}
+
+// Static returns a Provider that always returns key.
+func Static(key string) Provider { return staticProvider(key) }
+
+type staticProvider string
+
+func (p staticProvider) APIKey(context.Context) (string, error) { return string(p), nil }
+
+// Env returns a Provider that reads the key from the environment variable
+// name on every request. It fails if the variable is unset or empty.
+func Env(name string) Provider { return envProvider(name) }
+
+type envProvider string
+
+func (p envProvider) APIKey(context.Context) (string, error) {
+	key := os.Getenv(string(p))
+	if key == "" {
+		return "", fmt.Errorf("credentials: environment variable %s is not set", string(p))
+	}
+	return key, nil
+}
+
+// FileProvider reads the key from a file and reloads it when the file's size
+// or modification time changes, as happens when a mounted Kubernetes secret
+// is updated. Surrounding whitespace is trimmed.
+type FileProvider struct {
+	path string
+
+	mu      sync.Mutex
+	key     string
+	size    int64
+	modTime time.Time
+}
+
+// File returns a FileProvider for the key stored at path.
+func File(path string) *FileProvider {
+	return &FileProvider{path: path}
+}
+
+// APIKey implements Provider. If the file cannot be read after a key was
+// loaded, for example while a secret is being swapped, the last key is
+// returned.
+func (p *FileProvider) APIKey(context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return p.fallback(err)
+	}
+	if p.key != "" && info.Size() == p.size && info.ModTime().Equal(p.modTime) {
+		return p.key, nil
+	}
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return p.fallback(err)
+	}
+	key := string(bytes.TrimSpace(data))
+	if key == "" {
+		return p.fallback(fmt.Errorf("%s is empty", p.path))
+	}
+	p.key, p.size, p.modTime = key, info.Size(), info.ModTime()
+	return p.key, nil
+}
+
+func (p *FileProvider) fallback(err error) (string, error) {
+	if p.key != "" {
+		return p.key, nil
+	}
+	return "", fmt.Errorf("credentials: reading key file: %w", err)
+}
+
+// RotatingProvider returns keys from a list in turn, moving on to the next
+// key when the current one is rejected and wrapping around after the last.
+type RotatingProvider struct {
+	mu      sync.Mutex
+	keys    []string
+	current int
+}
+
+// Rotating returns a RotatingProvider that starts with the first of keys.
+func Rotating(keys ...string) *RotatingProvider {
+	return &RotatingProvider{keys: append([]string(nil), keys...)}
+}
+
+// APIKey implements Provider.
+func (p *RotatingProvider) APIKey(context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.keys) == 0 {
+		return "", fmt.Errorf("credentials: no keys to rotate")
+	}
+	return p.keys[p.current], nil
+}
+
+// Reject implements Rejecter. Only a rejection of the current key rotates,
+// so concurrent requests failing with the same key skip just one key.
+func (p *RotatingProvider) Reject(key string, _ int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.keys) > 0 && p.keys[p.current] == key {
+		p.current = (p.current + 1) % len(p.keys)
+	}
+}
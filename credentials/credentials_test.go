@@ -0,0 +1,86 @@
+package credentials_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/astro-api/astroapi-go/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var ctx = context.Background()
+
+func TestStatic(t *testing.T) {
+	key, err := credentials.Static("k1").APIKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "k1", key)
+}
+
+func TestEnv(t *testing.T) {
+	p := credentials.Env("ASTRO_TEST_KEY")
+
+	t.Setenv("ASTRO_TEST_KEY", "")
+	_, err := p.APIKey(ctx)
+	assert.ErrorContains(t, err, "ASTRO_TEST_KEY")
+
+	t.Setenv("ASTRO_TEST_KEY", "k1")
+	key, err := p.APIKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "k1", key)
+}
+
+func TestFile_Reloads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(path, []byte("k1\n"), 0o600))
+	p := credentials.File(path)
+
+	key, err := p.APIKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "k1", key)
+
+	require.NoError(t, os.WriteFile(path, []byte("k2-rotated\n"), 0o600))
+	key, err = p.APIKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "k2-rotated", key)
+
+	// Same size, newer modification time.
+	require.NoError(t, os.WriteFile(path, []byte("k3-rotated"), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	key, err = p.APIKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "k3-rotated", key)
+
+	// The last key is kept while the file is missing.
+	require.NoError(t, os.Remove(path))
+	key, err = p.APIKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "k3-rotated", key)
+}
+
+func TestFile_Missing(t *testing.T) {
+	_, err := credentials.File(filepath.Join(t.TempDir(), "key")).APIKey(ctx)
+	assert.ErrorContains(t, err, "reading key file")
+}
+
+func TestRotating(t *testing.T) {
+	p := credentials.Rotating("k1", "k2")
+
+	key, _ := p.APIKey(ctx)
+	assert.Equal(t, "k1", key)
+
+	p.Reject("k1", 401)
+	p.Reject("k1", 401) // a stale rejection does not skip k2
+	key, _ = p.APIKey(ctx)
+	assert.Equal(t, "k2", key)
+
+	p.Reject("k2", 429)
+	key, _ = p.APIKey(ctx)
+	assert.Equal(t, "k1", key)
+
+	_, err := credentials.Rotating().APIKey(ctx)
+	assert.Error(t, err)
+}
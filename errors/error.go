@@ -2,10 +2,16 @@
 package errors
 
 import (
+	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
+
+	"github.com/astro-api/astroapi-go/internal/headers"
 )
 
 // apiErrorBody represents the error structure returned by the Astrology API.
@@ -20,6 +26,51 @@ type apiErrorBody struct {
 	Message string `json:"message"`
 }
 
+// Sentinel errors classify an AstrologyError by status code. Match them with
+// errors.Is:
+//
+//	if errors.Is(err, astroerrors.ErrRateLimited) { ... }
+var (
+	// ErrUnauthorized matches 401 and 403 responses: the API key is missing,
+	// invalid or not allowed to use the endpoint.
+	ErrUnauthorized = stderrors.New("astrology API: unauthorized")
+	// ErrNotFound matches 404 responses.
+	ErrNotFound = stderrors.New("astrology API: not found")
+	// ErrValidation matches 400 and 422 responses: the request parameters
+	// were rejected.
+	ErrValidation = stderrors.New("astrology API: validation failed")
+	// ErrRateLimited matches 429 responses.
+	ErrRateLimited = stderrors.New("astrology API: rate limited")
+	// ErrServer matches 5xx responses.
+	ErrServer = stderrors.New("astrology API: server error")
+	// ErrCircuitOpen is returned without sending the request while the
+	// circuit breaker set with option.WithCircuitBreaker is open for the
+	// endpoint.
+	ErrCircuitOpen = stderrors.New("astrology API: circuit open")
+)
+
+// Timeout errors name the deadline that ended a call. Both also match
+// context.DeadlineExceeded. A shorter deadline on the caller's context
+// applies as well and is reported as plain context.DeadlineExceeded.
+var (
+	// ErrAttemptTimeout is returned when a single attempt ran past the
+	// timeout set with option.WithAttemptTimeout and no retry was left.
+	ErrAttemptTimeout error = deadlineError("astrology API: attempt timed out")
+	// ErrRequestTimeout is returned when the whole call, including retries,
+	// ran past the timeout set with option.WithRequestTimeout.
+	ErrRequestTimeout error = deadlineError("astrology API: request timed out")
+)
+
+type deadlineError string
+
+func (e deadlineError) Error() string { return string(e) }
+
+// Timeout reports true, like net.Error.
+func (deadlineError) Timeout() bool { return true }
+
+// Is makes the error match context.DeadlineExceeded.
+func (deadlineError) Is(target error) bool { return target == context.DeadlineExceeded }
+
 // AstrologyError represents an error returned by the Astrology API.
 type AstrologyError struct {
 	StatusCode int
@@ -31,16 +82,54 @@ type AstrologyError struct {
 	Message string
 	// Code is the machine-readable error code extracted from the body.
 	Code string
+	// Field names the request parameter the error refers to, if any.
+	Field string
+	// RequestID is the X-Request-Id response header, for correlating with
+	// API-side logs.
+	RequestID string
+	// RetryAfter is how long the server asked the client to wait before
+	// retrying, taken from Retry-After or X-RateLimit-Reset. Zero if absent.
+	RetryAfter time.Duration
 }
 
 // Error implements the error interface.
 func (e *AstrologyError) Error() string {
-	if e.Message != "" {
+	switch {
+	case e.Message != "" && e.Field != "":
+		return fmt.Sprintf("astrology API error %d: %s (field %s)", e.StatusCode, e.Message, e.Field)
+	case e.Message != "":
 		return fmt.Sprintf("astrology API error %d: %s", e.StatusCode, e.Message)
 	}
 	return fmt.Sprintf("astrology API error %d", e.StatusCode)
 }
 
+// Is reports whether target is the sentinel error matching e's status code.
+func (e *AstrologyError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.IsNotFound()
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return e.IsRateLimit()
+	case ErrServer:
+		return e.IsServerError()
+	}
+	return false
+}
+
+// Temporary reports whether the error is likely transient: a timeout, a
+// rate limit or a server error.
+func (e *AstrologyError) Temporary() bool {
+	return e.StatusCode == http.StatusRequestTimeout || e.IsRateLimit() || e.IsServerError()
+}
+
+// Retryable reports whether repeating the same request may succeed, after
+// waiting RetryAfter if it is set.
+func (e *AstrologyError) Retryable() bool { return e.Temporary() }
+
 // IsNotFound reports whether this is a 404 error.
 func (e *AstrologyError) IsNotFound() bool { return e.StatusCode == http.StatusNotFound }
 
@@ -58,18 +147,28 @@ func (e *AstrologyError) IsClientError() bool {
 // NewFromResponse constructs an AstrologyError from an http.Response whose
 // status code is not 2xx. It reads the response body.
 func NewFromResponse(req *http.Request, resp *http.Response) *AstrologyError {
-	var bodyStr string
+	var body []byte
 	if resp.Body != nil {
-		b, _ := io.ReadAll(resp.Body)
+		body, _ = io.ReadAll(resp.Body)
 		resp.Body.Close()
-		bodyStr = string(b)
 	}
+	return NewFromBody(req, resp, body)
+}
 
+// NewFromBody constructs an AstrologyError from a response whose body has
+// already been read. It is used for 2xx responses that report
+// {"success": false}.
+func NewFromBody(req *http.Request, resp *http.Response, body []byte) *AstrologyError {
+	bodyStr := string(body)
 	ae := &AstrologyError{
 		StatusCode: resp.StatusCode,
 		Request:    req,
 		Response:   resp,
 		Body:       bodyStr,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+	}
+	if wait, ok := headers.RetryAfter(resp.StatusCode, resp.Header, time.Now()); ok {
+		ae.RetryAfter = wait
 	}
 
 	// Try to parse JSON error body.
@@ -78,6 +177,7 @@ func NewFromResponse(req *http.Request, resp *http.Response) *AstrologyError {
 		if errBody.Error != nil {
 			ae.Message = errBody.Error.Message
 			ae.Code = errBody.Error.ErrorCode
+			ae.Field = errBody.Error.Field
 		} else if errBody.Message != "" {
 			ae.Message = errBody.Message
 		}
@@ -85,3 +185,61 @@ func NewFromResponse(req *http.Request, resp *http.Response) *AstrologyError {
 
 	return ae
 }
+
+// DecodeError reports a successful response whose body could not be decoded
+// into the result type. It is only returned when strict decoding is enabled
+// with option.WithStrictDecoding.
+type DecodeError struct {
+	// Path is the JSON path of the offending value, starting at the envelope
+	// key, e.g. "data.planets.0.degree". Empty if the path is unknown.
+	Path string
+	// Err is the underlying encoding/json error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	if e.Path != "" {
+		return fmt.Sprintf("decoding response at %s: %v", e.Path, e.Err)
+	}
+	return fmt.Sprintf("decoding response: %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// Violation is a single invalid request parameter.
+type Violation struct {
+	// Path is the JSON path of the parameter, e.g. "subject1.birth_data.year".
+	Path string
+	// Rule is the name of the failed rule, e.g. "required".
+	Rule string
+	// Message describes the problem, e.g. "is required".
+	Message string
+}
+
+// String returns the path followed by the message.
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	return v.Path + " " + v.Message
+}
+
+// ValidationError reports every invalid parameter found before a request was
+// sent. It matches ErrValidation with errors.Is.
+type ValidationError struct {
+	Violations []Violation
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.String()
+	}
+	return "validation error: " + strings.Join(msgs, "; ")
+}
+
+// Is reports whether target is ErrValidation.
+func (e *ValidationError) Is(target error) bool { return target == ErrValidation }
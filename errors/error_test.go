@@ -1,10 +1,13 @@
 package errors_test
 
 import (
+	stderrors "errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	astroerrors "github.com/astro-api/astroapi-go/errors"
 	"github.com/stretchr/testify/assert"
@@ -29,6 +32,8 @@ func TestAstrologyError_ParseBody(t *testing.T) {
 	assert.Equal(t, 401, err.StatusCode)
 	assert.Equal(t, "Invalid API key", err.Message)
 	assert.Equal(t, "INVALID_KEY", err.Code)
+	assert.Equal(t, "api_key", err.Field)
+	assert.Contains(t, err.Error(), "field api_key")
 }
 
 func TestAstrologyError_Error(t *testing.T) {
@@ -75,3 +80,61 @@ func TestAstrologyError_InvalidJSON(t *testing.T) {
 	assert.Equal(t, "not json", err.Body)
 	assert.Empty(t, err.Message)
 }
+
+func TestAstrologyError_RetryAfter(t *testing.T) {
+	req, resp := makeResponse(429, `{}`)
+	resp.Header = http.Header{}
+	resp.Header.Set("Retry-After", "30")
+	err := astroerrors.NewFromResponse(req, resp)
+	assert.Equal(t, 30*time.Second, err.RetryAfter)
+}
+
+func TestAstrologyError_RequestID(t *testing.T) {
+	req, resp := makeResponse(500, `{}`)
+	resp.Header = http.Header{}
+	resp.Header.Set("X-Request-Id", "req-123")
+	err := astroerrors.NewFromResponse(req, resp)
+	assert.Equal(t, "req-123", err.RequestID)
+}
+
+func TestAstrologyError_Sentinels(t *testing.T) {
+	tests := []struct {
+		status   int
+		sentinel error
+	}{
+		{401, astroerrors.ErrUnauthorized},
+		{403, astroerrors.ErrUnauthorized},
+		{404, astroerrors.ErrNotFound},
+		{400, astroerrors.ErrValidation},
+		{422, astroerrors.ErrValidation},
+		{429, astroerrors.ErrRateLimited},
+		{502, astroerrors.ErrServer},
+	}
+	all := []error{
+		astroerrors.ErrUnauthorized, astroerrors.ErrNotFound, astroerrors.ErrValidation,
+		astroerrors.ErrRateLimited, astroerrors.ErrServer,
+	}
+	for _, tt := range tests {
+		req, resp := makeResponse(tt.status, `{}`)
+		var err error = fmt.Errorf("wrapped: %w", astroerrors.NewFromResponse(req, resp))
+		for _, s := range all {
+			assert.Equal(t, s == tt.sentinel, stderrors.Is(err, s), "status %d vs %v", tt.status, s)
+		}
+	}
+}
+
+func TestAstrologyError_Retryable(t *testing.T) {
+	for status, want := range map[int]bool{400: false, 401: false, 404: false, 408: true, 429: true, 500: true, 503: true} {
+		req, resp := makeResponse(status, `{}`)
+		err := astroerrors.NewFromResponse(req, resp)
+		assert.Equal(t, want, err.Retryable(), "status %d", status)
+		assert.Equal(t, want, err.Temporary(), "status %d", status)
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	inner := stderrors.New("cannot unmarshal string")
+	err := &astroerrors.DecodeError{Path: "data.sun.degree", Err: inner}
+	assert.Contains(t, err.Error(), "data.sun.degree")
+	assert.ErrorIs(t, err, inner)
+}
@@ -44,10 +44,10 @@ func main() {
 		Name: "Demo User",
 		BirthData: shared.BirthData{
 			Year:        1990,
-			Month:       5,
-			Day:         11,
-			Hour:        14,
-			Minute:      30,
+			Month:       shared.F(5),
+			Day:         shared.F(11),
+			Hour:        shared.F(14),
+			Minute:      shared.F(30),
 			City:        "London",
 			CountryCode: "GB",
 		},
@@ -0,0 +1,259 @@
+// Package form encodes request params as URL query values.
+//
+// Struct fields are named by their `url` tag, falling back to the `json` tag
+// and then the field name; `url:"-"` skips a field and `omitempty` drops
+// zero values. Numbers and booleans are written with strconv, values that
+// implement encoding.TextMarshaler (such as shared.Date or time.Time) with
+// MarshalText, and slices of scalars as repeated keys. Nested structs and
+// maps are flattened with bracket ("a[b]") or dotted ("a.b") keys.
+package form
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Nesting selects how keys of nested structs, maps and slice elements are
+// joined to their parent key.
+type Nesting int
+
+const (
+	// Brackets joins nested keys as parent[child].
+	Brackets Nesting = iota
+	// Dots joins nested keys as parent.child.
+	Dots
+)
+
+// Encode returns the query values for params, which may be a struct, a map
+// with string keys or a pointer to either. nil params yield nil values.
+// Repeated values keep their order; url.Values.Encode sorts the keys, so the
+// encoded query is stable and can be used as a cache key.
+func Encode(params any, nesting Nesting) (url.Values, error) {
+	v := reflect.ValueOf(params)
+	v, ok := indirect(v)
+	if !ok {
+		return nil, nil
+	}
+	e := encoder{nesting: nesting, values: url.Values{}}
+	switch v.Kind() {
+	case reflect.Struct, reflect.Map:
+		if err := e.encode("", v, false); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("form: cannot encode %s as query parameters", v.Type())
+	}
+	return e.values, nil
+}
+
+type encoder struct {
+	nesting Nesting
+	values  url.Values
+}
+
+func (e *encoder) key(parent, child string) string {
+	switch {
+	case parent == "":
+		return child
+	case e.nesting == Dots:
+		return parent + "." + child
+	}
+	return parent + "[" + child + "]"
+}
+
+func (e *encoder) add(key, value string) {
+	e.values[key] = append(e.values[key], value)
+}
+
+// encode writes v under key. omitEmpty drops zero values.
+func (e *encoder) encode(key string, v reflect.Value, omitEmpty bool) error {
+	if inner, set, ok := unwrapOptional(v); ok {
+		if !set {
+			return nil
+		}
+		// An explicitly set optional value is written even if it is zero.
+		v, omitEmpty = inner, false
+	}
+	v, ok := indirect(v)
+	if !ok {
+		return nil
+	}
+	if omitEmpty && v.IsZero() {
+		return nil
+	}
+
+	if s, ok, err := marshalText(v); ok {
+		if err != nil {
+			return fmt.Errorf("form: encoding %s: %w", key, err)
+		}
+		e.add(key, s)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return e.encodeStruct(key, v)
+	case reflect.Map:
+		return e.encodeMap(key, v)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			elem, ok := indirect(v.Index(i))
+			if !ok {
+				continue
+			}
+			if s, ok := scalar(elem); ok {
+				e.add(key, s)
+				continue
+			}
+			if err := e.encode(e.key(key, strconv.Itoa(i)), elem, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	s, ok := scalar(v)
+	if !ok {
+		return fmt.Errorf("form: cannot encode %s of type %s", key, v.Type())
+	}
+	e.add(key, s)
+	return nil
+}
+
+func (e *encoder) encodeStruct(key string, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, omitEmpty, skip := fieldName(field)
+		if skip {
+			continue
+		}
+		fv := v.Field(i)
+		// Embedded structs without a name are flattened into the parent.
+		if field.Anonymous && name == "" {
+			if inner, ok := indirect(fv); ok && inner.Kind() == reflect.Struct {
+				if err := e.encodeStruct(key, inner); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if err := e.encode(e.key(key, name), fv, omitEmpty); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *encoder) encodeMap(key string, v reflect.Value) error {
+	if v.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("form: cannot encode map with %s keys", v.Type().Key())
+	}
+	keys := make([]string, 0, v.Len())
+	for _, k := range v.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := e.encode(e.key(key, k), v.MapIndex(reflect.ValueOf(k).Convert(v.Type().Key())), false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldName returns the query key of a struct field from its url or json
+// tag. name is empty if the tag does not set one.
+func fieldName(f reflect.StructField) (name string, omitEmpty, skip bool) {
+	tag, ok := f.Tag.Lookup("url")
+	if !ok {
+		tag = f.Tag.Get("json")
+	}
+	if tag == "-" {
+		return "", false, true
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	for _, o := range strings.Split(opts, ",") {
+		if o == "omitempty" || o == "omitzero" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
+
+// indirect dereferences pointers and interfaces. ok is false for nil.
+func indirect(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, v.IsValid()
+}
+
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+func marshalText(v reflect.Value) (string, bool, error) {
+	if !v.Type().Implements(textMarshalerType) {
+		if !v.CanAddr() || !reflect.PointerTo(v.Type()).Implements(textMarshalerType) {
+			return "", false, nil
+		}
+		v = v.Addr()
+	}
+	b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+	return string(b), true, err
+}
+
+// scalar formats strings, numbers and booleans.
+func scalar(v reflect.Value) (string, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32), true
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), true
+	}
+	return "", false
+}
+
+// optional is implemented by apijson.Field.
+type optional interface {
+	IsPresent() bool
+	IsNull() bool
+}
+
+// unwrapOptional returns the Value of an apijson.Field and whether it holds
+// a non-null value. ok is false if v is not a Field.
+func unwrapOptional(v reflect.Value) (inner reflect.Value, set bool, ok bool) {
+	if v.Kind() != reflect.Struct || !v.CanInterface() {
+		return v, false, false
+	}
+	o, isOpt := v.Interface().(optional)
+	if !isOpt {
+		return v, false, false
+	}
+	inner = v.FieldByName("Value")
+	if !inner.IsValid() {
+		return v, false, false
+	}
+	return inner, o.IsPresent() && !o.IsNull(), true
+}
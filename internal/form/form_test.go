@@ -0,0 +1,100 @@
+package form_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/astro-api/astroapi-go/internal/form"
+	"github.com/astro-api/astroapi-go/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type place struct {
+	City string  `url:"city"`
+	Lat  float64 `url:"lat,omitempty"`
+}
+
+type params struct {
+	Search   string            `json:"search_json" url:"search,omitempty"`
+	Limit    int               `url:"limit,omitempty"`
+	Big      float64           `url:"big"`
+	Small    float32           `url:"small"`
+	Flag     bool              `url:"flag"`
+	Tags     []string          `url:"tag,omitempty"`
+	Place    *place            `url:"place,omitempty"`
+	Stops    []place           `url:"stops,omitempty"`
+	Extra    map[string]any    `url:"extra,omitempty"`
+	Date     shared.Date       `url:"date,omitzero"`
+	Hour     shared.Field[int] `url:"hour,omitzero"`
+	Skipped  string            `url:"-"`
+	JSONOnly string            `json:"json_only,omitempty"`
+}
+
+func TestEncode_Nil(t *testing.T) {
+	values, err := form.Encode(nil, form.Brackets)
+	require.NoError(t, err)
+	assert.Nil(t, values)
+
+	var p *params
+	values, err = form.Encode(p, form.Brackets)
+	require.NoError(t, err)
+	assert.Nil(t, values)
+}
+
+func TestEncode_Brackets(t *testing.T) {
+	p := &params{
+		Search:   "new york",
+		Big:      123456789012,
+		Small:    0.1,
+		Flag:     false,
+		Tags:     []string{"b", "a"},
+		Place:    &place{City: "Paris", Lat: 48.8566},
+		Stops:    []place{{City: "Rome"}},
+		Extra:    map[string]any{"z": 1, "a": map[string]any{"x": true}},
+		Date:     shared.NewDate(2024, time.May, 11),
+		Hour:     shared.F(0),
+		Skipped:  "secret",
+		JSONOnly: "yes",
+	}
+	values, err := form.Encode(p, form.Brackets)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"big=123456789012&date=2024-05-11&extra%5Ba%5D%5Bx%5D=true&extra%5Bz%5D=1&flag=false"+
+			"&hour=0&json_only=yes&place%5Bcity%5D=Paris&place%5Blat%5D=48.8566&search=new+york"+
+			"&small=0.1&stops%5B0%5D%5Bcity%5D=Rome&tag=b&tag=a",
+		values.Encode())
+}
+
+func TestEncode_Dots(t *testing.T) {
+	p := params{Place: &place{City: "Paris"}, Stops: []place{{City: "Rome"}}}
+	values, err := form.Encode(p, form.Dots)
+	require.NoError(t, err)
+	assert.Equal(t, "Paris", values.Get("place.city"))
+	assert.Equal(t, "Rome", values.Get("stops.0.city"))
+	assert.NotContains(t, values, "place.lat")
+}
+
+func TestEncode_OmitEmpty(t *testing.T) {
+	values, err := form.Encode(params{}, form.Brackets)
+	require.NoError(t, err)
+	// Only fields without omitempty are written.
+	assert.Equal(t, "big=0&flag=false&small=0", values.Encode())
+}
+
+func TestEncode_Map(t *testing.T) {
+	values, err := form.Encode(map[string]any{"b": 2.5, "a": "x"}, form.Brackets)
+	require.NoError(t, err)
+	assert.Equal(t, "a=x&b=2.5", values.Encode())
+}
+
+func TestEncode_Unsupported(t *testing.T) {
+	_, err := form.Encode("just a string", form.Brackets)
+	assert.Error(t, err)
+
+	_, err = form.Encode(struct {
+		C chan int `url:"c"`
+	}{C: make(chan int)}, form.Brackets)
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+// Package headers parses the rate-limit related HTTP response headers
+// returned by the Astrology API.
+package headers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// epochThreshold separates X-RateLimit-Reset values that are a delta in
+// seconds from values that are an absolute Unix timestamp.
+const epochThreshold = 1_000_000_000
+
+// RetryAfter returns how long the server asked the client to wait before
+// sending another request, given a response with statusCode and headers h.
+// It understands Retry-After in both its delta-seconds and HTTP-date forms.
+// X-RateLimit-Reset is only used in its place for a 429 response or when
+// X-RateLimit-Remaining is 0, as many APIs send it with every response.
+// ok is false when no usable header is present.
+func RetryAfter(statusCode int, h http.Header, now time.Time) (d time.Duration, ok bool) {
+	if h == nil {
+		return 0, false
+	}
+	if v := strings.TrimSpace(h.Get("Retry-After")); v != "" {
+		if secs, ok := parseSeconds(v); ok {
+			return time.Duration(secs) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return clamp(t.Sub(now)), true
+		}
+	}
+	if remaining, ok := RateLimitRemaining(h); statusCode != http.StatusTooManyRequests && (!ok || remaining > 0) {
+		return 0, false
+	}
+	return RateLimitReset(h, now)
+}
+
+// RateLimitReset parses X-RateLimit-Reset, which may be either a number of
+// seconds until the window resets or an absolute Unix timestamp.
+func RateLimitReset(h http.Header, now time.Time) (time.Duration, bool) {
+	if h == nil {
+		return 0, false
+	}
+	v := strings.TrimSpace(h.Get("X-RateLimit-Reset"))
+	if v == "" {
+		return 0, false
+	}
+	secs, ok := parseSeconds(v)
+	if !ok {
+		return 0, false
+	}
+	if secs >= epochThreshold {
+		return clamp(time.Unix(secs, 0).Sub(now)), true
+	}
+	return time.Duration(secs) * time.Second, true
+}
+
+// maxSeconds is the largest number of seconds a time.Duration can hold.
+const maxSeconds = int64(math.MaxInt64 / time.Second)
+
+// parseSeconds parses a non-negative integer number of seconds, the
+// delta-seconds form of RFC 9110. Values too large for a time.Duration are
+// saturated to maxSeconds.
+func parseSeconds(v string) (int64, bool) {
+	if v == "" {
+		return 0, false
+	}
+	for _, c := range v {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+	secs, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || secs > maxSeconds {
+		// Only a range error is possible for a string of digits.
+		return maxSeconds, true
+	}
+	return secs, true
+}
+
+func clamp(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// RateLimitRemaining parses X-RateLimit-Remaining, the number of requests
+// left in the current rate-limit window.
+func RateLimitRemaining(h http.Header) (int, bool) {
+	if h == nil {
+		return 0, false
+	}
+	v := strings.TrimSpace(h.Get("X-RateLimit-Remaining"))
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,100 @@
+package headers_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/astro-api/astroapi-go/internal/headers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryAfter_Seconds(t *testing.T) {
+	h := http.Header{}
+	h.Set("Retry-After", "7")
+	d, ok := headers.RetryAfter(http.StatusTooManyRequests, h, time.Now())
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, d)
+}
+
+func TestRetryAfter_HTTPDate(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	h := http.Header{}
+	h.Set("Retry-After", now.Add(90*time.Second).Format(http.TimeFormat))
+	d, ok := headers.RetryAfter(http.StatusTooManyRequests, h, now)
+	assert.True(t, ok)
+	assert.Equal(t, 90*time.Second, d)
+}
+
+func TestRetryAfter_PastDateIsZero(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	h := http.Header{}
+	h.Set("Retry-After", now.Add(-time.Minute).Format(http.TimeFormat))
+	d, ok := headers.RetryAfter(http.StatusTooManyRequests, h, now)
+	assert.True(t, ok)
+	assert.Zero(t, d)
+}
+
+func TestRetryAfter_RateLimitResetDelta(t *testing.T) {
+	h := http.Header{}
+	h.Set("X-RateLimit-Reset", "12")
+	d, ok := headers.RetryAfter(http.StatusTooManyRequests, h, time.Now())
+	assert.True(t, ok)
+	assert.Equal(t, 12*time.Second, d)
+}
+
+func TestRetryAfter_RateLimitResetEpoch(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	h := http.Header{}
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(30*time.Second).Unix(), 10))
+	d, ok := headers.RetryAfter(http.StatusTooManyRequests, h, now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, d)
+}
+
+func TestRetryAfter_Missing(t *testing.T) {
+	_, ok := headers.RetryAfter(http.StatusTooManyRequests, http.Header{}, time.Now())
+	assert.False(t, ok)
+
+	h := http.Header{}
+	h.Set("Retry-After", "soon")
+	_, ok = headers.RetryAfter(http.StatusTooManyRequests, h, time.Now())
+	assert.False(t, ok)
+}
+
+func TestRetryAfter_RejectsNonIntegers(t *testing.T) {
+	for _, v := range []string{"Inf", "NaN", "-5", "1.5", "1e3", "+3"} {
+		h := http.Header{}
+		h.Set("Retry-After", v)
+		_, ok := headers.RetryAfter(http.StatusTooManyRequests, h, time.Now())
+		assert.False(t, ok, v)
+	}
+}
+
+func TestRetryAfter_HugeValueSaturates(t *testing.T) {
+	for _, v := range []string{"99999999999", "99999999999999999999999"} {
+		h := http.Header{}
+		h.Set("Retry-After", v)
+		d, ok := headers.RetryAfter(http.StatusTooManyRequests, h, time.Now())
+		assert.True(t, ok, v)
+		assert.Greater(t, d, 100*365*24*time.Hour, v)
+	}
+}
+
+func TestRetryAfter_RoutineRateLimitResetIgnored(t *testing.T) {
+	h := http.Header{}
+	h.Set("X-RateLimit-Reset", "3600")
+	h.Set("X-RateLimit-Remaining", "990")
+	_, ok := headers.RetryAfter(http.StatusBadGateway, h, time.Now())
+	assert.False(t, ok)
+
+	d, ok := headers.RetryAfter(http.StatusTooManyRequests, h, time.Now())
+	assert.True(t, ok)
+	assert.Equal(t, time.Hour, d)
+
+	h.Set("X-RateLimit-Remaining", "0")
+	d, ok = headers.RetryAfter(http.StatusServiceUnavailable, h, time.Now())
+	assert.True(t, ok)
+	assert.Equal(t, time.Hour, d)
+}
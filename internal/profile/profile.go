@@ -0,0 +1,128 @@
+// Package profile loads named client configurations from a JSON file in the
+// user's config directory, e.g. ~/.config/astroapi/config.json:
+//
+//	{
+//	  "profiles": {
+//	    "staging": {
+//	      "api_key": "sk-staging-...",
+//	      "base_url": "https://staging.astrology-api.io",
+//	      "max_retries": 3,
+//	      "retry_delay": "250ms",
+//	      "request_timeout": "10s",
+//	      "headers": {"X-Team": "charts"},
+//	      "chart_options": {"house_system": "P", "language": "en"}
+//	    }
+//	  }
+//	}
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/astro-api/astroapi-go/internal/requestconfig"
+	"github.com/astro-api/astroapi-go/shared"
+)
+
+// PathEnv overrides the location of the config file.
+const PathEnv = "ASTROLOGY_API_CONFIG"
+
+// Profile is one named configuration. Unset fields leave the client
+// defaults in place.
+type Profile struct {
+	APIKey         string                   `json:"api_key"`
+	BaseURL        string                   `json:"base_url"`
+	MaxRetries     *int                     `json:"max_retries"`
+	RetryDelay     Duration                 `json:"retry_delay"`
+	RequestTimeout Duration                 `json:"request_timeout"`
+	Headers        map[string]string        `json:"headers"`
+	ChartOptions   *shared.AstrologyOptions `json:"chart_options"`
+}
+
+type file struct {
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+// Duration is a time.Duration written as a string such as "1.5s".
+type Duration time.Duration
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"30s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Path returns the config file location: $ASTROLOGY_API_CONFIG if set,
+// otherwise astroapi/config.json in os.UserConfigDir.
+func Path() (string, error) {
+	if p := os.Getenv(PathEnv); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locating config directory: %w", err)
+	}
+	return filepath.Join(dir, "astroapi", "config.json"), nil
+}
+
+// Load reads the named profile from the config file.
+func Load(name string) (*Profile, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading profile %q: %w", name, err)
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("loading profile %q: parsing %s: %w", name, path, err)
+	}
+	p, ok := f.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("loading profile %q: not found in %s", name, path)
+	}
+	return &p, nil
+}
+
+// Apply copies the fields set in p onto cfg.
+func (p *Profile) Apply(cfg *requestconfig.RequestConfig) {
+	if p.APIKey != "" {
+		cfg.APIKey = p.APIKey
+	}
+	if p.BaseURL != "" {
+		cfg.BaseURL = p.BaseURL
+	}
+	if p.MaxRetries != nil {
+		cfg.MaxRetries = *p.MaxRetries
+	}
+	if p.RetryDelay > 0 {
+		cfg.RetryDelay = time.Duration(p.RetryDelay)
+	}
+	if p.RequestTimeout > 0 {
+		cfg.RequestTimeout = time.Duration(p.RequestTimeout)
+	}
+	if len(p.Headers) > 0 && cfg.ExtraHeaders == nil {
+		cfg.ExtraHeaders = make(http.Header)
+	}
+	for k, v := range p.Headers {
+		cfg.ExtraHeaders.Set(k, v)
+	}
+	if p.ChartOptions != nil {
+		opts := *p.ChartOptions
+		cfg.DefaultAstrologyOptions = &opts
+	}
+}
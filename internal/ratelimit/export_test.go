@@ -0,0 +1,9 @@
+package ratelimit
+
+import "time"
+
+// SetNow replaces the clock of l for tests.
+func (l *Limiter) SetNow(now func() time.Time) { l.now = now }
+
+// Reserve exposes reserve for tests.
+func (l *Limiter) Reserve() time.Duration { return l.reserve() }
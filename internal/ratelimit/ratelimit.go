@@ -0,0 +1,164 @@
+// Package ratelimit provides a client-side token-bucket rate limiter that
+// adapts to the rate-limit feedback returned by the Astrology API.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	astroerrors "github.com/astro-api/astroapi-go/errors"
+	"github.com/astro-api/astroapi-go/internal/headers"
+)
+
+const (
+	// minRateFraction is the lowest fraction of the configured rate the
+	// limiter will back off to after repeated 429 responses.
+	minRateFraction = 0.05
+	// recoveryFraction is how much of the configured rate is regained after
+	// each successful response.
+	recoveryFraction = 0.1
+	// maxBlock is the longest the server can block the limiter for with
+	// Retry-After or X-RateLimit-Reset, matching the longest wait
+	// RetryTransport sleeps through.
+	maxBlock = time.Minute
+)
+
+// Limiter is a token-bucket rate limiter. It is safe for concurrent use.
+//
+// The effective rate starts at the configured rate. It is halved after every
+// 429 response and recovers gradually on success. When the server reports
+// that no requests remain in the current window, Wait blocks until the window
+// resets.
+type Limiter struct {
+	mu           sync.Mutex
+	maxRate      float64
+	rate         float64
+	burst        float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+	now          func() time.Time
+}
+
+// New creates a Limiter allowing rps requests per second with bursts of up
+// to burst requests. burst values below 1 are treated as 1.
+func New(rps float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		maxRate: rps,
+		rate:    rps,
+		burst:   float64(burst),
+		tokens:  float64(burst),
+		now:     time.Now,
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done. If the wait would
+// outlast the deadline of ctx, it fails at once with an error matching
+// errors.ErrRateLimited.
+func (l *Limiter) Wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay <= 0 {
+			return nil
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return fmt.Errorf("%w: client-side limit allows the next request in %s, after the deadline", astroerrors.ErrRateLimited, delay.Round(time.Millisecond))
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available and returns zero, or returns how
+// long the caller should sleep before trying again.
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Before(l.blockedUntil) {
+		return l.blockedUntil.Sub(now)
+	}
+	l.refill(now)
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+func (l *Limiter) refill(now time.Time) {
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+}
+
+// Observe adjusts the limiter using the status code and rate-limit headers
+// of a response.
+func (l *Limiter) Observe(statusCode int, h http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.refill(now)
+
+	if remaining, ok := headers.RateLimitRemaining(h); ok {
+		if float64(remaining) < l.tokens {
+			l.tokens = float64(remaining)
+		}
+		if remaining == 0 {
+			if reset, ok := headers.RateLimitReset(h, now); ok {
+				l.blockUntil(now, now.Add(reset))
+			}
+		}
+	}
+
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		l.rate /= 2
+		if floor := l.maxRate * minRateFraction; l.rate < floor {
+			l.rate = floor
+		}
+		l.tokens = 0
+		if wait, ok := headers.RetryAfter(statusCode, h, now); ok {
+			l.blockUntil(now, now.Add(wait))
+		}
+	case statusCode >= 200 && statusCode < 300:
+		l.rate += l.maxRate * recoveryFraction
+		if l.rate > l.maxRate {
+			l.rate = l.maxRate
+		}
+	}
+}
+
+// Rate returns the current effective rate in requests per second.
+func (l *Limiter) Rate() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rate
+}
+
+// blockUntil blocks the limiter until t, or for at most maxBlock from now.
+func (l *Limiter) blockUntil(now, t time.Time) {
+	if limit := now.Add(maxBlock); t.After(limit) {
+		t = limit
+	}
+	if t.After(l.blockedUntil) {
+		l.blockedUntil = t
+	}
+}
@@ -0,0 +1,105 @@
+package ratelimit_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	astroerrors "github.com/astro-api/astroapi-go/errors"
+	"github.com/astro-api/astroapi-go/internal/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_BurstThenThrottle(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	l := ratelimit.New(20, 3)
+	l.SetNow(func() time.Time { return now })
+
+	for i := 0; i < 3; i++ {
+		assert.Zero(t, l.Reserve(), "burst token %d", i)
+	}
+	assert.Equal(t, 50*time.Millisecond, l.Reserve())
+
+	now = now.Add(50 * time.Millisecond)
+	assert.Zero(t, l.Reserve())
+	assert.Equal(t, 50*time.Millisecond, l.Reserve())
+}
+
+func TestLimiter_ContextCancellation(t *testing.T) {
+	l := ratelimit.New(1, 1)
+	require.NoError(t, l.Wait(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	err := l.Wait(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLimiter_FailsFastBeyondDeadline(t *testing.T) {
+	l := ratelimit.New(1, 1)
+	require.NoError(t, l.Wait(context.Background()))
+
+	// The next token is a second away.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := l.Wait(ctx)
+	assert.ErrorIs(t, err, astroerrors.ErrRateLimited)
+	assert.NoError(t, ctx.Err(), "Wait returns before the deadline")
+}
+
+func TestLimiter_CapsServerBlock(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	l := ratelimit.New(1000, 10)
+	l.SetNow(func() time.Time { return now })
+
+	h := http.Header{}
+	h.Set("Retry-After", "86400")
+	l.Observe(http.StatusTooManyRequests, h)
+	assert.Equal(t, time.Minute, l.Reserve())
+
+	h = http.Header{}
+	h.Set("X-RateLimit-Remaining", "0")
+	h.Set("X-RateLimit-Reset", "99999999999")
+	l.Observe(http.StatusOK, h)
+	assert.Equal(t, time.Minute, l.Reserve())
+}
+
+func TestLimiter_SlowsDownOnRateLimit(t *testing.T) {
+	l := ratelimit.New(100, 1)
+	l.Observe(http.StatusTooManyRequests, nil)
+	assert.Equal(t, 50.0, l.Rate())
+	l.Observe(http.StatusTooManyRequests, nil)
+	assert.Equal(t, 25.0, l.Rate())
+
+	l.Observe(http.StatusOK, nil)
+	assert.Equal(t, 35.0, l.Rate())
+	for i := 0; i < 20; i++ {
+		l.Observe(http.StatusOK, nil)
+	}
+	assert.Equal(t, 100.0, l.Rate())
+}
+
+func TestLimiter_BlocksUntilWindowReset(t *testing.T) {
+	l := ratelimit.New(1000, 10)
+	h := http.Header{}
+	h.Set("X-RateLimit-Remaining", "0")
+	h.Set("X-RateLimit-Reset", "1")
+	l.Observe(http.StatusOK, h)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	assert.Error(t, l.Wait(ctx))
+}
+
+func TestLimiter_HonorsRetryAfterOn429(t *testing.T) {
+	l := ratelimit.New(1000, 10)
+	h := http.Header{}
+	h.Set("Retry-After", "1")
+	l.Observe(http.StatusTooManyRequests, h)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	assert.Error(t, l.Wait(ctx))
+}
@@ -0,0 +1,73 @@
+package requestconfig
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Hooks are callbacks for observing calls, e.g. to record OpenTelemetry spans
+// or metrics. Any of them may be nil. They run synchronously on the calling
+// goroutine, and may be called concurrently for concurrent calls.
+type Hooks struct {
+	// OnRequestStart is called before a call is validated and sent.
+	OnRequestStart func(RequestEvent)
+	// OnAttempt is called after each round trip, including retries.
+	OnAttempt func(AttemptEvent)
+	// OnResponse is called when a call succeeds with a response from the API.
+	OnResponse func(ResponseEvent)
+	// OnError is called when a call fails, including validation errors.
+	OnError func(ErrorEvent)
+	// OnCacheHit is called when a call is served from the response cache.
+	OnCacheHit func(RequestEvent)
+}
+
+// RequestEvent identifies a call.
+type RequestEvent struct {
+	// Context is the context the call was made with.
+	Context context.Context
+	// Endpoint names the SDK method, e.g. "charts.GetNatal" or
+	// "insights.relationship.GetCompatibility". Empty if it is unknown.
+	Endpoint string
+	Method   string
+	// URL is the request URL without the query string.
+	URL string
+}
+
+// AttemptEvent describes one round trip of a call.
+type AttemptEvent struct {
+	RequestEvent
+	// Attempt is the one-based attempt number.
+	Attempt int
+	// Delay is the backoff waited before the attempt; zero for the first.
+	Delay time.Duration
+	// StatusCode is zero if the attempt failed with a network error.
+	StatusCode int
+	Err        error
+	// Latency is the time until response headers were received.
+	Latency time.Duration
+}
+
+// ResponseEvent describes a successful call.
+type ResponseEvent struct {
+	RequestEvent
+	StatusCode int
+	Header     http.Header
+	// Attempts is the number of round trips made, including retries.
+	Attempts int
+	// Latency is the total time spent, including backoff.
+	Latency time.Duration
+}
+
+// ErrorEvent describes a failed call.
+type ErrorEvent struct {
+	RequestEvent
+	Err error
+	// StatusCode is the HTTP status of the final attempt; zero if no
+	// response was received.
+	StatusCode int
+	// Attempts is the number of round trips made, including retries.
+	Attempts int
+	// Latency is the total time spent, including backoff.
+	Latency time.Duration
+}
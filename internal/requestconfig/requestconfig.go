@@ -3,31 +3,129 @@
 package requestconfig
 
 import (
+	"log/slog"
 	"net/http"
+	"slices"
 	"time"
+
+	"github.com/astro-api/astroapi-go/cache"
+	"github.com/astro-api/astroapi-go/credentials"
+	"github.com/astro-api/astroapi-go/internal/form"
+	"github.com/astro-api/astroapi-go/internal/ratelimit"
+	"github.com/astro-api/astroapi-go/internal/transport"
+	"github.com/astro-api/astroapi-go/shared"
 )
 
 const (
-	DefaultBaseURL       = "https://api.astrology-api.io"
-	DefaultMaxRetries    = 2
-	DefaultRetryDelay    = 500 * time.Millisecond
+	DefaultBaseURL        = "https://api.astrology-api.io"
+	DefaultMaxRetries     = 2
+	DefaultRetryDelay     = 500 * time.Millisecond
 	DefaultRequestTimeout = 30 * time.Second
+	DefaultRetryJitter    = transport.JitterFull
+	// DefaultFailoverThreshold and DefaultFailoverCoolOff apply when
+	// several base URLs are configured.
+	DefaultFailoverThreshold = 3
+	DefaultFailoverCoolOff   = 30 * time.Second
 )
 
+// MiddlewareNext sends a request on to the next middleware, or to the
+// built-in retry and auth transports after the last one.
+type MiddlewareNext = func(*http.Request) (*http.Response, error)
+
+// Middleware wraps the sending of each request.
+type Middleware = func(req *http.Request, next MiddlewareNext) (*http.Response, error)
+
 // DefaultRetryStatusCodes are HTTP status codes that trigger a retry.
 var DefaultRetryStatusCodes = []int{408, 429, 500, 502, 503, 504}
 
 // RequestConfig holds all configuration for a single HTTP request.
 type RequestConfig struct {
-	APIKey             string
-	BaseURL            string
-	HTTPClient         *http.Client
-	MaxRetries         int
-	RetryDelay         time.Duration
-	RetryStatusCodes   []int
-	RequestTimeout     time.Duration
-	ExtraHeaders       http.Header
-	ResponseInto       **http.Response
+	APIKey           string
+	BaseURL          string
+	HTTPClient       *http.Client
+	MaxRetries       int
+	RetryDelay       time.Duration
+	RetryJitter      transport.Jitter
+	RetryStatusCodes []int
+	RequestTimeout   time.Duration
+	AttemptTimeout   time.Duration
+	ExtraHeaders     http.Header
+	ResponseInto     **http.Response
+	ResponseMeta     *ResponseMeta
+	// IdempotencyKey is sent with non-GET requests. When empty, MakeRequest
+	// generates a fresh key for each call.
+	IdempotencyKey string
+	// QueryNesting selects how nested GET params are flattened into query keys.
+	QueryNesting form.Nesting
+	// RateLimiter is shared by every request made with this config and its clones.
+	RateLimiter *ratelimit.Limiter
+	// Cache stores successful responses for requests with a positive CacheTTL.
+	Cache    cache.Cache
+	CacheTTL time.Duration
+	// StrictDecoding reports envelope and schema mismatches as errors instead
+	// of falling back to decoding the whole body.
+	StrictDecoding        bool
+	DisallowUnknownFields bool
+	// Profile names the config-file profile to load; only read by NewClient.
+	Profile string
+	// DefaultAstrologyOptions and DefaultReportOptions are merged into the
+	// chart and report options of each request; values set on the request win.
+	DefaultAstrologyOptions *shared.AstrologyOptions
+	DefaultReportOptions    *shared.ReportOptions
+	// Breaker fails calls fast while the API keeps failing; nil disables it.
+	// It is shared by every request made with this config and its clones.
+	Breaker *transport.Breaker
+	// Credentials supplies the API key for each attempt; APIKey is used
+	// when it is nil.
+	Credentials credentials.Provider
+	// Endpoints holds the base URLs to fail over between, the first of which
+	// is BaseURL, and their health. nil disables failover.
+	Endpoints *transport.Endpoints
+	// FailoverThreshold consecutive 5xx responses or connection errors mark
+	// an endpoint down for FailoverCoolOff.
+	FailoverThreshold int
+	FailoverCoolOff   time.Duration
+	// Hooks observe every call made with this config.
+	Hooks *Hooks
+	// Logger receives a line per call, retries, and at debug level the
+	// request and response bodies. LogRedactPII masks personal data of
+	// subjects in logged bodies.
+	Logger       *slog.Logger
+	LogRedactPII bool
+	// Middlewares run in order around the transport stack of every request.
+	Middlewares []Middleware
+	// Err is a configuration error, such as a missing profile, returned by
+	// every request made with this config.
+	Err error
+}
+
+// ResponseMeta describes how a single call was served. It is filled in by
+// MakeRequest when requested with option.WithResponseMeta, for successful and
+// failed calls alike.
+type ResponseMeta struct {
+	// StatusCode is the HTTP status of the final attempt; zero if no response
+	// was received.
+	StatusCode int
+	// RequestID is the server-assigned X-Request-Id, useful for correlating
+	// with API-side logs.
+	RequestID string
+	// RateLimitRemaining is the X-RateLimit-Remaining value, or -1 if absent.
+	RateLimitRemaining int
+	// RateLimitReset is the time until the rate-limit window resets, from
+	// X-RateLimit-Reset. Zero if absent.
+	RateLimitReset time.Duration
+	// Attempts is the number of round trips made, including retries.
+	Attempts int
+	// AttemptLatencies holds the time to response headers for each attempt.
+	AttemptLatencies []time.Duration
+	// Latency is the total time spent, including backoff and reading the body.
+	Latency time.Duration
+	// Header holds the response headers of the final attempt.
+	Header http.Header
+	// Body is the raw response body.
+	Body []byte
+	// Cached reports whether the response was served from the response cache.
+	Cached bool
 }
 
 // NewDefault returns a RequestConfig populated with default values.
@@ -36,9 +134,13 @@ func NewDefault() *RequestConfig {
 		BaseURL:          DefaultBaseURL,
 		MaxRetries:       DefaultMaxRetries,
 		RetryDelay:       DefaultRetryDelay,
+		RetryJitter:      DefaultRetryJitter,
 		RequestTimeout:   DefaultRequestTimeout,
 		RetryStatusCodes: append([]int(nil), DefaultRetryStatusCodes...),
 		ExtraHeaders:     make(http.Header),
+
+		FailoverThreshold: DefaultFailoverThreshold,
+		FailoverCoolOff:   DefaultFailoverCoolOff,
 	}
 }
 
@@ -55,6 +157,7 @@ func (rc *RequestConfig) Clone() *RequestConfig {
 	if rc.ExtraHeaders != nil {
 		clone.ExtraHeaders = rc.ExtraHeaders.Clone()
 	}
+	clone.Middlewares = slices.Clone(rc.Middlewares)
 	return &clone
 }
 
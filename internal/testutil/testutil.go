@@ -34,16 +34,17 @@ type MockHandler func(w http.ResponseWriter, r *http.Request)
 
 // NewClient always creates an AstrologyClient backed by a local mock httptest.Server.
 // The mock handler is always called regardless of whether ASTROLOGY_API_KEY is set.
+// Extra options are applied after the test defaults.
 // Use this for unit tests. The caller must invoke the returned cleanup function.
-func NewClient(t *testing.T, mock MockHandler) (client *astroapi.AstrologyClient, cleanup func()) {
+func NewClient(t *testing.T, mock MockHandler, opts ...option.RequestOption) (client *astroapi.AstrologyClient, cleanup func()) {
 	t.Helper()
 	srv := httptest.NewServer(http.HandlerFunc(mock))
 	t.Cleanup(srv.Close)
-	return astroapi.NewClient(
+	return astroapi.NewClient(append([]option.RequestOption{
 		option.WithAPIKey("test-key"),
 		option.WithBaseURL(srv.URL),
 		option.WithMaxRetries(0),
-	), srv.Close
+	}, opts...)...), srv.Close
 }
 
 // NewIntegrationClient returns a client pointed at the real API.
@@ -84,10 +85,10 @@ func DefaultSubject() shared.Subject {
 		Name: "Test User",
 		BirthData: shared.BirthData{
 			Year:        1990,
-			Month:       5,
-			Day:         11,
-			Hour:        14,
-			Minute:      30,
+			Month:       shared.F(5),
+			Day:         shared.F(11),
+			Hour:        shared.F(14),
+			Minute:      shared.F(30),
 			City:        "London",
 			CountryCode: "GB",
 		},
@@ -100,10 +101,10 @@ func DefaultSubject2() shared.Subject {
 		Name: "Test User 2",
 		BirthData: shared.BirthData{
 			Year:        1992,
-			Month:       3,
-			Day:         27,
-			Hour:        9,
-			Minute:      0,
+			Month:       shared.F(3),
+			Day:         shared.F(27),
+			Hour:        shared.F(9),
+			Minute:      shared.F(0),
 			City:        "Paris",
 			CountryCode: "FR",
 		},
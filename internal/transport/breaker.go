@@ -0,0 +1,254 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	astroerrors "github.com/astro-api/astroapi-go/errors"
+)
+
+// Default circuit breaker settings, used for zero BreakerConfig fields.
+const (
+	DefaultBreakerFailureRatio     = 0.5
+	DefaultBreakerMinRequests      = 10
+	DefaultBreakerWindow           = time.Minute
+	DefaultBreakerCoolOff          = 30 * time.Second
+	DefaultBreakerHalfOpenRequests = 1
+)
+
+// BreakerConfig configures a Breaker. Zero fields take the defaults above.
+type BreakerConfig struct {
+	// FailureRatio is the share of failed calls in a window that opens the
+	// circuit.
+	FailureRatio float64
+	// MinRequests is the number of calls in a window before the ratio is
+	// considered.
+	MinRequests int
+	// Window is how long calls are counted before the counts start over.
+	Window time.Duration
+	// CoolOff is how long the circuit stays open before probing.
+	CoolOff time.Duration
+	// HalfOpenRequests is the number of probe calls let through at once
+	// while half-open.
+	HalfOpenRequests int
+}
+
+// BreakerState is the state of the circuit of one endpoint.
+type BreakerState int
+
+const (
+	// BreakerClosed lets calls through and counts failures.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails calls fast until the cool-off has passed.
+	BreakerOpen
+	// BreakerHalfOpen lets a few probe calls through; a success closes the
+	// circuit and a failure opens it again.
+	BreakerHalfOpen
+)
+
+// String returns the name of the state.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// Breaker tracks a circuit per endpoint. It is safe for concurrent use and
+// shared by every transport stack built from the same config.
+type Breaker struct {
+	cfg BreakerConfig
+	now func() time.Time
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	state       BreakerState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probes      int
+}
+
+// NewBreaker returns a Breaker with all circuits closed.
+func NewBreaker(cfg BreakerConfig) *Breaker {
+	if cfg.FailureRatio <= 0 {
+		cfg.FailureRatio = DefaultBreakerFailureRatio
+	}
+	if cfg.MinRequests <= 0 {
+		cfg.MinRequests = DefaultBreakerMinRequests
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultBreakerWindow
+	}
+	if cfg.CoolOff <= 0 {
+		cfg.CoolOff = DefaultBreakerCoolOff
+	}
+	if cfg.HalfOpenRequests <= 0 {
+		cfg.HalfOpenRequests = DefaultBreakerHalfOpenRequests
+	}
+	return &Breaker{cfg: cfg, now: time.Now, circuits: make(map[string]*circuit)}
+}
+
+// State returns the state of the circuit for endpoint, as named by
+// WithEndpoint or, for requests without one, the URL path.
+func (b *Breaker) State(endpoint string) BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c, ok := b.circuits[endpoint]
+	if !ok {
+		return BreakerClosed
+	}
+	if c.state == BreakerOpen && !b.now().Before(c.openedAt.Add(b.cfg.CoolOff)) {
+		return BreakerHalfOpen
+	}
+	return c.state
+}
+
+// allow reports whether a call to endpoint may be made. probe is true if
+// the call is a half-open probe.
+func (b *Breaker) allow(endpoint string) (ok, probe bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.circuit(endpoint)
+	now := b.now()
+	switch c.state {
+	case BreakerOpen:
+		if now.Before(c.openedAt.Add(b.cfg.CoolOff)) {
+			return false, false
+		}
+		c.state, c.probes = BreakerHalfOpen, 0
+		fallthrough
+	case BreakerHalfOpen:
+		if c.probes >= b.cfg.HalfOpenRequests {
+			return false, false
+		}
+		c.probes++
+		return true, true
+	}
+	if now.Sub(c.windowStart) >= b.cfg.Window {
+		c.windowStart, c.requests, c.failures = now, 0, 0
+	}
+	return true, false
+}
+
+// record stores the outcome of a call to endpoint that allow let through.
+func (b *Breaker) record(endpoint string, probe, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.circuit(endpoint)
+	now := b.now()
+	if probe {
+		c.probes--
+		if c.state != BreakerHalfOpen {
+			return
+		}
+		if failed {
+			c.state, c.openedAt = BreakerOpen, now
+			return
+		}
+		*c = circuit{state: BreakerClosed, windowStart: now}
+		return
+	}
+	if c.state != BreakerClosed {
+		return
+	}
+	c.requests++
+	if failed {
+		c.failures++
+	}
+	if c.requests >= b.cfg.MinRequests && float64(c.failures)/float64(c.requests) >= b.cfg.FailureRatio {
+		c.state, c.openedAt = BreakerOpen, now
+	}
+}
+
+// release forgets a call to endpoint that allow let through without
+// counting it.
+func (b *Breaker) release(endpoint string, probe bool) {
+	if !probe {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.circuit(endpoint).probes--
+}
+
+func (b *Breaker) circuit(endpoint string) *circuit {
+	c, ok := b.circuits[endpoint]
+	if !ok {
+		c = &circuit{windowStart: b.now()}
+		b.circuits[endpoint] = c
+	}
+	return c
+}
+
+type endpointKey struct{}
+
+// WithEndpoint returns a copy of ctx naming the endpoint a request is made
+// for, e.g. "tarot.GetCard". BreakerTransport keys circuits by it, so that
+// the calls of one endpoint share a circuit whatever IDs their URL paths
+// hold.
+func WithEndpoint(ctx context.Context, endpoint string) context.Context {
+	return context.WithValue(ctx, endpointKey{}, endpoint)
+}
+
+// endpointOf returns the endpoint named by WithEndpoint in the context of
+// req, or its URL path if there is none.
+func endpointOf(req *http.Request) string {
+	if e, _ := req.Context().Value(endpointKey{}).(string); e != "" {
+		return e
+	}
+	return req.URL.Path
+}
+
+// BreakerTransport fails calls fast with errors.ErrCircuitOpen while the
+// circuit of their endpoint is open. It wraps RetryTransport, so a call
+// counts once however many attempts it took. Connection errors, 5xx
+// responses and request timeouts count as failures; calls cancelled by the
+// caller are not counted.
+type BreakerTransport struct {
+	Breaker *Breaker
+	Base    http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *BreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := endpointOf(req)
+	ok, probe := t.Breaker.allow(endpoint)
+	if !ok {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, fmt.Errorf("%w for %s", astroerrors.ErrCircuitOpen, endpoint)
+	}
+	resp, err := t.base().RoundTrip(req)
+	if err != nil && callerCancelled(req.Context()) {
+		t.Breaker.release(endpoint, probe)
+		return resp, err
+	}
+	t.Breaker.record(endpoint, probe, err != nil || resp.StatusCode >= 500)
+	return resp, err
+}
+
+// callerCancelled reports whether ctx was ended by the caller rather than
+// by the SDK's request timeout.
+func callerCancelled(ctx context.Context) bool {
+	return ctx.Err() != nil && !errors.Is(context.Cause(ctx), astroerrors.ErrRequestTimeout)
+}
+
+func (t *BreakerTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
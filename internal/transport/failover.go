@@ -0,0 +1,175 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	astroerrors "github.com/astro-api/astroapi-go/errors"
+)
+
+// Endpoints is an ordered list of base URLs with passive health tracking.
+// It is shared by every FailoverTransport built from the same config, so
+// health observed by one request applies to all of them.
+type Endpoints struct {
+	mu     sync.Mutex
+	urls   []*url.URL
+	health []endpointHealth
+	now    func() time.Time
+}
+
+type endpointHealth struct {
+	failures  int
+	downUntil time.Time
+}
+
+// NewEndpoints parses the base URLs, the first of which is the primary.
+func NewEndpoints(baseURLs []string) (*Endpoints, error) {
+	if len(baseURLs) == 0 {
+		return nil, fmt.Errorf("no base URLs")
+	}
+	e := &Endpoints{health: make([]endpointHealth, len(baseURLs)), now: time.Now}
+	for _, raw := range baseURLs {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parsing base URL %q: %w", raw, err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("parsing base URL %q: scheme and host are required", raw)
+		}
+		u.Path = strings.TrimRight(u.Path, "/")
+		e.urls = append(e.urls, u)
+	}
+	return e, nil
+}
+
+// Primary returns the first base URL.
+func (e *Endpoints) Primary() string { return e.urls[0].String() }
+
+// pick returns the endpoint for the given (zero-based) attempt: the first
+// healthy endpoint for the first attempt and the next healthy one for each
+// retry. If every endpoint is down, all of them are tried in order.
+func (e *Endpoints) pick(attempt int) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := e.now()
+	healthy := make([]int, 0, len(e.urls))
+	for i, h := range e.health {
+		if !now.Before(h.downUntil) {
+			healthy = append(healthy, i)
+		}
+	}
+	if len(healthy) == 0 {
+		return attempt % len(e.urls)
+	}
+	return healthy[attempt%len(healthy)]
+}
+
+// report records the outcome of a request to endpoint i. After threshold
+// consecutive failures the endpoint is down for coolOff. An endpoint back
+// from cool-off is marked down again by its next failure.
+func (e *Endpoints) report(i int, ok bool, threshold int, coolOff time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	h := &e.health[i]
+	if ok {
+		*h = endpointHealth{}
+		return
+	}
+	h.failures++
+	if h.failures >= threshold {
+		h.downUntil = e.now().Add(coolOff)
+		h.failures = threshold - 1
+	}
+}
+
+// rewrite returns the URL of req on endpoint i, or false if req is not
+// addressed to the primary endpoint.
+func (e *Endpoints) rewrite(u *url.URL, i int) (*url.URL, bool) {
+	primary := e.urls[0]
+	if u.Scheme != primary.Scheme || u.Host != primary.Host || !strings.HasPrefix(u.Path, primary.Path) {
+		return nil, false
+	}
+	target := e.urls[i]
+	out := *u
+	out.Scheme, out.Host = target.Scheme, target.Host
+	out.Path = target.Path + strings.TrimPrefix(u.Path, primary.Path)
+	out.RawPath = ""
+	return &out, true
+}
+
+// FailoverTransport sends requests addressed to the primary endpoint to a
+// healthy endpoint instead. RetryTransport tags each attempt with its index,
+// so retries move on to the next healthy endpoint. Connection errors and 5xx
+// responses count as failures, as do attempts that run past their
+// AttemptTimeout; requests cancelled by the caller or ended by the overall
+// request timeout do not.
+type FailoverTransport struct {
+	Endpoints *Endpoints
+	// Threshold is the number of consecutive failures that marks an endpoint
+	// down; values below 1 are treated as 1.
+	Threshold int
+	// CoolOff is how long an endpoint stays down.
+	CoolOff time.Duration
+	Base    http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *FailoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.Endpoints == nil {
+		return t.base().RoundTrip(req)
+	}
+	i := t.Endpoints.pick(attemptFrom(req.Context()))
+	target, ok := t.Endpoints.rewrite(req.URL, i)
+	if !ok {
+		return t.base().RoundTrip(req)
+	}
+	clone := req.Clone(req.Context())
+	clone.URL = target
+	clone.Host = ""
+
+	resp, err := t.base().RoundTrip(clone)
+	if err != nil && endedByClient(req.Context()) {
+		// Cancelled by the caller or out of time for the whole request;
+		// that says nothing about the endpoint. A per-attempt timeout does
+		// count, as the endpoint failed to answer in time.
+		return resp, err
+	}
+	threshold := t.Threshold
+	if threshold < 1 {
+		threshold = 1
+	}
+	t.Endpoints.report(i, err == nil && resp.StatusCode < 500, threshold, t.CoolOff)
+	return resp, err
+}
+
+func (t *FailoverTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// endedByClient reports whether ctx was cancelled by the caller or ended by
+// the overall request timeout, rather than by the attempt timeout.
+func endedByClient(ctx context.Context) bool {
+	return ctx.Err() != nil && !errors.Is(context.Cause(ctx), astroerrors.ErrAttemptTimeout)
+}
+
+type attemptKey struct{}
+
+// withAttempt returns a copy of ctx carrying the zero-based attempt index.
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// attemptFrom returns the attempt index attached to ctx, or zero.
+func attemptFrom(ctx context.Context) int {
+	n, _ := ctx.Value(attemptKey{}).(int)
+	return n
+}
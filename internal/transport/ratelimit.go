@@ -0,0 +1,38 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/astro-api/astroapi-go/internal/ratelimit"
+)
+
+// RateLimitTransport waits for Limiter before every request and feeds it
+// every response. It sits inside RetryTransport, so each retry attempt takes
+// a token and a 429 slows the limiter down even if a retry then succeeds.
+type RateLimitTransport struct {
+	Limiter *ratelimit.Limiter
+	Base    http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.Limiter.Wait(req.Context()); err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, fmt.Errorf("waiting for rate limiter: %w", err)
+	}
+	resp, err := t.base().RoundTrip(req)
+	if err == nil {
+		t.Limiter.Observe(resp.StatusCode, resp.Header)
+	}
+	return resp, err
+}
+
+func (t *RateLimitTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
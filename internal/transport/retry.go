@@ -2,13 +2,42 @@ package transport
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"math"
+	"math/rand/v2"
 	"net/http"
 	"time"
+
+	astroerrors "github.com/astro-api/astroapi-go/errors"
+	"github.com/astro-api/astroapi-go/internal/headers"
+)
+
+const (
+	maxBackoffCap = 30 * time.Second
+	// maxRetryAfter is the longest server-requested wait RetryTransport will
+	// sleep through. Longer waits return the response to the caller instead.
+	maxRetryAfter = time.Minute
 )
 
-const maxBackoffCap = 30 * time.Second
+// IdempotencyKeyHeader carries the key that lets the API deduplicate retried
+// non-idempotent requests.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// Jitter selects how RetryTransport randomises the delay between attempts.
+type Jitter int
+
+const (
+	// JitterNone uses plain exponential backoff.
+	JitterNone Jitter = iota
+	// JitterFull waits a random duration between zero and the exponential backoff.
+	JitterFull
+	// JitterDecorrelated waits a random duration between InitialDelay and three
+	// times the previous delay, which spreads out clients that failed together.
+	JitterDecorrelated
+)
 
 // RetryTransport wraps a base RoundTripper with configurable retry logic.
 // It buffers the request body so retries work correctly.
@@ -17,10 +46,18 @@ type RetryTransport struct {
 	MaxRetries       int
 	InitialDelay     time.Duration
 	RetryStatusCodes []int
+	Jitter           Jitter
+	// AttemptTimeout bounds each attempt, including reading the body of the
+	// response that is returned. Zero means no per-attempt limit.
+	AttemptTimeout time.Duration
 }
 
 // RoundTrip executes the request, retrying on network errors or configured status codes
-// with exponential backoff capped at maxBackoffCap.
+// with exponential backoff capped at maxBackoffCap. When a 429 or 503 response carries
+// Retry-After or X-RateLimit-Reset, the server-requested wait is used as a lower bound.
+// Network errors are only retried for requests that are safe to replay: idempotent
+// methods and requests carrying an Idempotency-Key header. An attempt that runs
+// past AttemptTimeout counts as a network error.
 func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	// Buffer the body so it can be replayed on retry.
 	var bodyBytes []byte
@@ -34,50 +71,126 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	}
 
 	var (
-		resp *http.Response
-		err  error
+		resp  *http.Response
+		err   error
+		delay time.Duration
 	)
+	trace := TraceFrom(req.Context())
 
 	for attempt := 0; attempt <= t.MaxRetries; attempt++ {
-		// Check context before each attempt (except the first).
+		// Wait before each attempt (except the first).
 		if attempt > 0 {
-			delay := t.backoff(attempt - 1)
+			timer := time.NewTimer(delay)
 			select {
 			case <-req.Context().Done():
+				timer.Stop()
 				return nil, req.Context().Err()
-			case <-time.After(delay):
+			case <-timer.C:
 			}
 		}
 
-		// Rebuild request body for each attempt.
-		cloned := req.Clone(req.Context())
+		// Rebuild request body for each attempt. The attempt index lets
+		// FailoverTransport move retries to another endpoint.
+		ctx, cancel := t.attemptContext(withAttempt(req.Context(), attempt))
+		cloned := req.Clone(ctx)
 		if bodyBytes != nil {
 			cloned.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 			cloned.ContentLength = int64(len(bodyBytes))
 		}
 
+		started := time.Now()
 		resp, err = t.base().RoundTrip(cloned)
 		if err != nil {
-			// Network error — retry.
-			if attempt < t.MaxRetries {
+			cancel()
+			if errors.Is(context.Cause(ctx), astroerrors.ErrAttemptTimeout) {
+				err = fmt.Errorf("%w after %s: %w", astroerrors.ErrAttemptTimeout, t.AttemptTimeout, err)
+			}
+		}
+		trace.record(attemptOf(resp, err, time.Since(started), delay))
+		if err != nil {
+			// Network error — the server may already have processed the
+			// request, so only retry when replaying it is safe. A rate
+			// limiter that cannot let the request through before the
+			// deadline will not do so on a retry either.
+			if attempt < t.MaxRetries && replayable(req) && !errors.Is(err, astroerrors.ErrRateLimited) {
+				delay = t.backoff(attempt, delay)
 				continue
 			}
 			return nil, err
 		}
 
 		if t.shouldRetryStatus(resp.StatusCode) && attempt < t.MaxRetries {
+			delay = t.backoff(attempt, delay)
+			if wait, ok := serverWait(resp); ok {
+				// Give up early if the server wants us to wait longer than we
+				// are willing to, or longer than the caller's deadline allows.
+				if wait > maxRetryAfter || exceedsDeadline(req, wait) {
+					return t.keepAttempt(ctx, cancel, resp), nil
+				}
+				if wait > delay {
+					delay = wait
+				}
+			}
 			// Drain and close the body to allow connection reuse.
 			_, _ = io.Copy(io.Discard, resp.Body)
 			_ = resp.Body.Close()
+			cancel()
 			continue
 		}
 
-		return resp, nil
+		return t.keepAttempt(ctx, cancel, resp), nil
 	}
 
 	return resp, err
 }
 
+// attemptContext returns the context of one attempt, bounded by AttemptTimeout.
+func (t *RetryTransport) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if t.AttemptTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeoutCause(ctx, t.AttemptTimeout, astroerrors.ErrAttemptTimeout)
+}
+
+// keepAttempt returns resp with a body that releases the attempt context
+// ctx once it is closed.
+func (t *RetryTransport) keepAttempt(ctx context.Context, cancel context.CancelFunc, resp *http.Response) *http.Response {
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, ctx: ctx, cancel: cancel, timeout: t.AttemptTimeout}
+	return resp
+}
+
+// cancelOnClose releases the attempt context once the body is closed, and
+// names the attempt timeout if it cut a read short.
+type cancelOnClose struct {
+	io.ReadCloser
+	ctx     context.Context
+	cancel  context.CancelFunc
+	timeout time.Duration
+}
+
+func (b *cancelOnClose) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF && errors.Is(context.Cause(b.ctx), astroerrors.ErrAttemptTimeout) {
+		err = fmt.Errorf("%w after %s: %w", astroerrors.ErrAttemptTimeout, b.timeout, err)
+	}
+	return n, err
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// serverWait returns the wait the server asked for with a 429 or 503
+// response. Other statuses are retried on the normal backoff.
+func serverWait(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	return headers.RetryAfter(resp.StatusCode, resp.Header, time.Now())
+}
+
 func (t *RetryTransport) shouldRetryStatus(code int) bool {
 	for _, s := range t.RetryStatusCodes {
 		if s == code {
@@ -87,11 +200,35 @@ func (t *RetryTransport) shouldRetryStatus(code int) bool {
 	return false
 }
 
-func (t *RetryTransport) backoff(attempt int) time.Duration {
+// replayable reports whether req may be sent again after a network error.
+func replayable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// backoff returns the delay to wait after the given (zero-based) attempt.
+// prev is the previous delay and is only used by JitterDecorrelated.
+func (t *RetryTransport) backoff(attempt int, prev time.Duration) time.Duration {
 	delay := t.InitialDelay * time.Duration(math.Pow(2, float64(attempt)))
-	if delay > maxBackoffCap {
+	if delay > maxBackoffCap || delay < 0 {
 		delay = maxBackoffCap
 	}
+
+	switch t.Jitter {
+	case JitterFull:
+		delay = randBetween(0, delay)
+	case JitterDecorrelated:
+		if prev < t.InitialDelay {
+			prev = t.InitialDelay
+		}
+		delay = randBetween(t.InitialDelay, prev*3)
+		if delay > maxBackoffCap {
+			delay = maxBackoffCap
+		}
+	}
 	return delay
 }
 
@@ -101,3 +238,25 @@ func (t *RetryTransport) base() http.RoundTripper {
 	}
 	return http.DefaultTransport
 }
+
+// randBetween returns a random duration in [lo, hi].
+func randBetween(lo, hi time.Duration) time.Duration {
+	if hi <= lo {
+		return lo
+	}
+	return lo + time.Duration(rand.Int64N(int64(hi-lo)+1))
+}
+
+func attemptOf(resp *http.Response, err error, latency, delay time.Duration) Attempt {
+	a := Attempt{Err: err, Latency: latency, Delay: delay}
+	if resp != nil {
+		a.StatusCode = resp.StatusCode
+	}
+	return a
+}
+
+// exceedsDeadline reports whether waiting d would run past the request's deadline.
+func exceedsDeadline(req *http.Request, d time.Duration) bool {
+	deadline, ok := req.Context().Deadline()
+	return ok && time.Until(deadline) < d
+}
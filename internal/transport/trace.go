@@ -0,0 +1,48 @@
+package transport
+
+import (
+	"context"
+	"time"
+)
+
+// Attempt describes one round trip made by RetryTransport.
+type Attempt struct {
+	// StatusCode is zero if the attempt failed with a network error.
+	StatusCode int
+	Err        error
+	// Latency is the time until response headers were received.
+	Latency time.Duration
+	// Delay is the backoff waited before the attempt; zero for the first.
+	Delay time.Duration
+}
+
+// Trace collects the attempts made for one logical request. Attach it to the
+// request context with WithTrace; RetryTransport records into it.
+type Trace struct {
+	Attempts []Attempt
+	// OnAttempt, if set, is called after each attempt with its one-based
+	// number.
+	OnAttempt func(n int, a Attempt)
+}
+
+type traceKey struct{}
+
+// WithTrace returns a copy of ctx carrying t.
+func WithTrace(ctx context.Context, t *Trace) context.Context {
+	return context.WithValue(ctx, traceKey{}, t)
+}
+
+// TraceFrom returns the Trace attached to ctx, or nil.
+func TraceFrom(ctx context.Context) *Trace {
+	t, _ := ctx.Value(traceKey{}).(*Trace)
+	return t
+}
+
+func (t *Trace) record(a Attempt) {
+	if t != nil {
+		t.Attempts = append(t.Attempts, a)
+		if t.OnAttempt != nil {
+			t.OnAttempt(len(t.Attempts), a)
+		}
+	}
+}
@@ -5,12 +5,20 @@ package transport
 import (
 	"fmt"
 	"net/http"
+	"time"
+
+	"github.com/astro-api/astroapi-go/credentials"
+	"github.com/astro-api/astroapi-go/internal/headers"
 )
 
 // AuthTransport injects Authorization and Content-Type headers into every request.
+// The key comes from Credentials if set, otherwise from APIKey. When the API
+// rejects a key and Credentials implements credentials.Rejecter, the provider
+// is told so it can move on to another key.
 type AuthTransport struct {
-	APIKey string
-	Base   http.RoundTripper
+	APIKey      string
+	Credentials credentials.Provider
+	Base        http.RoundTripper
 }
 
 // RoundTrip clones the request, injects auth headers, and delegates to the base transport.
@@ -19,8 +27,15 @@ func (t *AuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	if clone.Header == nil {
 		clone.Header = make(http.Header)
 	}
-	if t.APIKey != "" && clone.Header.Get("Authorization") == "" {
-		clone.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.APIKey))
+	var key string
+	if clone.Header.Get("Authorization") == "" {
+		var err error
+		if key, err = t.apiKey(req); err != nil {
+			return nil, err
+		}
+		if key != "" {
+			clone.Header.Set("Authorization", fmt.Sprintf("Bearer %s", key))
+		}
 	}
 	if clone.Header.Get("Content-Type") == "" && req.Body != nil {
 		clone.Header.Set("Content-Type", "application/json")
@@ -28,7 +43,46 @@ func (t *AuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	if clone.Header.Get("Accept") == "" {
 		clone.Header.Set("Accept", "application/json")
 	}
-	return t.base().RoundTrip(clone)
+	resp, err := t.base().RoundTrip(clone)
+	if err == nil && key != "" && rejectsKey(resp) {
+		if r, ok := t.Credentials.(credentials.Rejecter); ok {
+			r.Reject(key, resp.StatusCode)
+		}
+	}
+	return resp, err
+}
+
+func (t *AuthTransport) apiKey(req *http.Request) (string, error) {
+	if t.Credentials == nil {
+		return t.APIKey, nil
+	}
+	key, err := t.Credentials.APIKey(req.Context())
+	if err != nil {
+		return "", fmt.Errorf("resolving API key: %w", err)
+	}
+	return key, nil
+}
+
+// quotaReset is the shortest X-RateLimit-Reset that makes a 429 an
+// exhausted quota rather than throttling that clears within moments.
+const quotaReset = 10 * time.Minute
+
+// rejectsKey reports whether resp means the key is invalid or out of quota.
+// A 429 only counts when X-RateLimit-Remaining is 0 and the window resets
+// at least quotaReset from now; other 429s are short-lived throttling that
+// rotating keys would not help with.
+func rejectsKey(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusPaymentRequired:
+		return true
+	case http.StatusTooManyRequests:
+		if remaining, ok := headers.RateLimitRemaining(resp.Header); !ok || remaining > 0 {
+			return false
+		}
+		reset, ok := headers.RateLimitReset(resp.Header, time.Now())
+		return ok && reset >= quotaReset
+	}
+	return false
 }
 
 func (t *AuthTransport) base() http.RoundTripper {
@@ -10,6 +10,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/astro-api/astroapi-go/credentials"
+	astroerrors "github.com/astro-api/astroapi-go/errors"
+	"github.com/astro-api/astroapi-go/internal/ratelimit"
 	"github.com/astro-api/astroapi-go/internal/transport"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -56,6 +59,82 @@ func TestAuthTransport_DoesNotOverwriteExistingAuth(t *testing.T) {
 	assert.Equal(t, "Bearer custom-key", gotAuth)
 }
 
+func TestAuthTransport_RotatesRejectedKey(t *testing.T) {
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") == "Bearer k1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	// Retries ask the provider again, so the retry uses the next key.
+	client := &http.Client{Transport: &transport.RetryTransport{
+		Base:             &transport.AuthTransport{Credentials: credentials.Rotating("k1", "k2")},
+		MaxRetries:       1,
+		RetryStatusCodes: []int{401},
+	}}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"Bearer k1", "Bearer k2"}, keys)
+}
+
+func TestAuthTransport_RotatesOnlyOnExhaustedQuota(t *testing.T) {
+	cases := []struct {
+		name    string
+		header  map[string]string
+		rotates bool
+	}{
+		{"no headers", nil, false},
+		{"requests left", map[string]string{"X-RateLimit-Remaining": "5", "X-RateLimit-Reset": "3600"}, false},
+		{"window resets soon", map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "30"}, false},
+		{"quota exhausted", map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "3600"}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tc.header {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+			}))
+			defer srv.Close()
+
+			provider := credentials.Rotating("k1", "k2")
+			client := &http.Client{Transport: &transport.AuthTransport{Credentials: provider}}
+			req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			key, err := provider.APIKey(context.Background())
+			require.NoError(t, err)
+			if tc.rotates {
+				assert.Equal(t, "k2", key)
+			} else {
+				assert.Equal(t, "k1", key)
+			}
+		})
+	}
+}
+
+func TestAuthTransport_CredentialsError(t *testing.T) {
+	t.Setenv("ASTRO_TEST_KEY", "")
+	client := &http.Client{Transport: &transport.AuthTransport{Credentials: credentials.Env("ASTRO_TEST_KEY")}}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:0", nil)
+	_, err := client.Do(req)
+	assert.ErrorContains(t, err, "resolving API key")
+}
+
 func TestRetryTransport_RetriesOn503(t *testing.T) {
 	var callCount int32
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -164,3 +243,526 @@ func TestRetryTransport_ReplayBody(t *testing.T) {
 	assert.Equal(t, body, bodies[0])
 	assert.Equal(t, body, bodies[1])
 }
+
+func TestRetryTransport_HonorsRetryAfterSeconds(t *testing.T) {
+	var callCount int32
+	var first, second time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&callCount, 1)
+		if n == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		second = time.Now()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	rt := &transport.RetryTransport{
+		MaxRetries:       1,
+		InitialDelay:     time.Millisecond,
+		RetryStatusCodes: []int{429},
+		Jitter:           transport.JitterFull,
+	}
+	client := &http.Client{Transport: rt}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, second.Sub(first), 900*time.Millisecond)
+}
+
+func TestRetryTransport_HonorsRetryAfterHTTPDate(t *testing.T) {
+	var callCount int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&callCount, 1)
+		if n == 1 {
+			w.Header().Set("Retry-After", time.Now().Add(2*time.Second).UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	rt := &transport.RetryTransport{
+		MaxRetries:       1,
+		InitialDelay:     time.Millisecond,
+		RetryStatusCodes: []int{503},
+	}
+	client := &http.Client{Transport: rt}
+
+	start := time.Now()
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	// HTTP dates have one-second resolution.
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+}
+
+func TestRetryTransport_RetryAfterBeyondDeadlineReturnsResponse(t *testing.T) {
+	var callCount int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&callCount, 1)
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	rt := &transport.RetryTransport{
+		MaxRetries:       3,
+		InitialDelay:     time.Millisecond,
+		RetryStatusCodes: []int{429},
+	}
+	client := &http.Client{Transport: rt}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&callCount))
+}
+
+func TestRetryTransport_HugeRetryAfterReturnsResponse(t *testing.T) {
+	var callCount int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&callCount, 1)
+		w.Header().Set("Retry-After", "99999999999")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	rt := &transport.RetryTransport{
+		MaxRetries:       3,
+		InitialDelay:     time.Millisecond,
+		RetryStatusCodes: []int{429},
+	}
+	client := &http.Client{Transport: rt}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&callCount))
+}
+
+func TestRetryTransport_IgnoresRoutineRateLimitResetOn5xx(t *testing.T) {
+	var callCount int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Reset", "3600")
+		w.Header().Set("X-RateLimit-Remaining", "990")
+		if atomic.AddInt32(&callCount, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	rt := &transport.RetryTransport{
+		MaxRetries:       2,
+		InitialDelay:     time.Millisecond,
+		RetryStatusCodes: []int{502},
+	}
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&callCount))
+}
+
+func TestRetryTransport_JitterStrategies(t *testing.T) {
+	for _, jitter := range []transport.Jitter{transport.JitterNone, transport.JitterFull, transport.JitterDecorrelated} {
+		var callCount int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&callCount, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		rt := &transport.RetryTransport{
+			MaxRetries:       3,
+			InitialDelay:     5 * time.Millisecond,
+			RetryStatusCodes: []int{503},
+			Jitter:           jitter,
+		}
+		client := &http.Client{Transport: rt}
+
+		start := time.Now()
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		srv.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&callCount))
+		assert.Less(t, time.Since(start), time.Second)
+	}
+}
+
+func TestRetryTransport_RecordsTrace(t *testing.T) {
+	var callCount int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&callCount, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	rt := &transport.RetryTransport{
+		MaxRetries:       2,
+		InitialDelay:     time.Millisecond,
+		RetryStatusCodes: []int{503},
+	}
+	client := &http.Client{Transport: rt}
+
+	trace := &transport.Trace{}
+	req, _ := http.NewRequestWithContext(transport.WithTrace(context.Background(), trace), http.MethodGet, srv.URL, nil)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Len(t, trace.Attempts, 2)
+	assert.Equal(t, http.StatusServiceUnavailable, trace.Attempts[0].StatusCode)
+	assert.Equal(t, http.StatusOK, trace.Attempts[1].StatusCode)
+	assert.NoError(t, trace.Attempts[1].Err)
+}
+
+// dropFirstConn returns a server that closes the connection without a
+// response on the first call, simulating a network error after the request
+// was sent.
+func dropFirstConn(t *testing.T, calls *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			_ = conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestRetryTransport_PostNetworkErrorNotRetriedWithoutIdempotencyKey(t *testing.T) {
+	var callCount int32
+	srv := dropFirstConn(t, &callCount)
+	defer srv.Close()
+
+	client := &http.Client{Transport: &transport.RetryTransport{MaxRetries: 2, InitialDelay: time.Millisecond}}
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(`{}`))
+	_, err := client.Do(req)
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&callCount))
+}
+
+func TestRetryTransport_PostNetworkErrorRetriedWithIdempotencyKey(t *testing.T) {
+	var callCount int32
+	srv := dropFirstConn(t, &callCount)
+	defer srv.Close()
+
+	client := &http.Client{Transport: &transport.RetryTransport{MaxRetries: 2, InitialDelay: time.Millisecond}}
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(`{}`))
+	req.Header.Set(transport.IdempotencyKeyHeader, "key-1")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&callCount))
+}
+
+func TestRateLimitTransport_ObservesEveryAttempt(t *testing.T) {
+	var callCount int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&callCount, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	limiter := ratelimit.New(100, 10)
+	rt := &transport.RetryTransport{
+		Base:             &transport.RateLimitTransport{Limiter: limiter},
+		MaxRetries:       1,
+		InitialDelay:     time.Millisecond,
+		RetryStatusCodes: []int{429},
+	}
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	// Halved by the retried 429, then recovered by 10% by the success.
+	assert.Equal(t, 60.0, limiter.Rate())
+}
+
+func TestRateLimitTransport_RetriesTakeTokens(t *testing.T) {
+	var callCount int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&callCount, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	rt := &transport.RetryTransport{
+		Base:             &transport.RateLimitTransport{Limiter: ratelimit.New(0.001, 2)},
+		MaxRetries:       3,
+		InitialDelay:     time.Millisecond,
+		RetryStatusCodes: []int{503},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	_, err := (&http.Client{Transport: rt}).Do(req)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, astroerrors.ErrRateLimited)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&callCount), "the third attempt waits for a token")
+}
+
+func TestFailoverTransport_RetriesOnNextEndpoint(t *testing.T) {
+	var primaryCalls, fallbackCalls int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&primaryCalls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer primary.Close()
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fallbackCalls, 1)
+		assert.Equal(t, "/v3/now", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer fallback.Close()
+
+	endpoints, err := transport.NewEndpoints([]string{primary.URL + "/api", fallback.URL + "/v3/"})
+	require.NoError(t, err)
+	client := &http.Client{Transport: &transport.RetryTransport{
+		Base: &transport.FailoverTransport{
+			Endpoints: endpoints,
+			Threshold: 2,
+			CoolOff:   100 * time.Millisecond,
+		},
+		MaxRetries:       1,
+		RetryStatusCodes: []int{502},
+	}}
+	get := func() {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, primary.URL+"/api/now", nil)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// Each call fails on the primary and is retried on the fallback.
+	get()
+	get()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&primaryCalls))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fallbackCalls))
+
+	// Two consecutive failures marked the primary down.
+	get()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&primaryCalls))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&fallbackCalls))
+
+	// After the cool-off the primary is tried again.
+	time.Sleep(150 * time.Millisecond)
+	get()
+	assert.Equal(t, int32(3), atomic.LoadInt32(&primaryCalls))
+}
+
+func TestFailoverTransport_IgnoresClientTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	var primaryCalls int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&primaryCalls, 1)
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer primary.Close()
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer fallback.Close()
+
+	endpoints, err := transport.NewEndpoints([]string{primary.URL, fallback.URL})
+	require.NoError(t, err)
+	client := &http.Client{Transport: &transport.FailoverTransport{Endpoints: endpoints, Threshold: 1, CoolOff: time.Minute}}
+
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, primary.URL+"/now", nil)
+		_, err := client.Do(req)
+		cancel()
+		require.Error(t, err)
+	}
+	// Neither timeout marked the primary down.
+	assert.Equal(t, int32(2), atomic.LoadInt32(&primaryCalls))
+}
+
+func TestFailoverTransport_CountsAttemptTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	var primaryCalls, fallbackCalls int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&primaryCalls, 1)
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer primary.Close()
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fallbackCalls, 1)
+	}))
+	defer fallback.Close()
+
+	endpoints, err := transport.NewEndpoints([]string{primary.URL, fallback.URL})
+	require.NoError(t, err)
+	client := &http.Client{Transport: &transport.RetryTransport{
+		Base:           &transport.FailoverTransport{Endpoints: endpoints, Threshold: 1, CoolOff: time.Minute},
+		MaxRetries:     1,
+		InitialDelay:   time.Millisecond,
+		AttemptTimeout: 20 * time.Millisecond,
+	}}
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, primary.URL+"/now", nil)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	// The hanging primary was marked down by its attempt timeout.
+	assert.Equal(t, int32(1), atomic.LoadInt32(&primaryCalls))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fallbackCalls))
+}
+
+func TestFailoverTransport_LeavesOtherHostsAlone(t *testing.T) {
+	var calls int32
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer other.Close()
+
+	endpoints, err := transport.NewEndpoints([]string{"http://127.0.0.1:1", "http://127.0.0.1:2"})
+	require.NoError(t, err)
+	client := &http.Client{Transport: &transport.FailoverTransport{Endpoints: endpoints}}
+
+	req, _ := http.NewRequest(http.MethodGet, other.URL, nil)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestBreakerTransport_OpensAndRecovers(t *testing.T) {
+	var calls, healthy int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.URL.Path == "/ok" || atomic.LoadInt32(&healthy) == 1 {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	breaker := transport.NewBreaker(transport.BreakerConfig{MinRequests: 4, FailureRatio: 0.5, CoolOff: 50 * time.Millisecond})
+	client := &http.Client{Transport: &transport.BreakerTransport{Breaker: breaker}}
+	get := func(path string) error {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	// Failures are counted per path, and only from MinRequests calls on.
+	for i := 0; i < 4; i++ {
+		require.NoError(t, get("/ok"))
+	}
+	for i := 0; i < 3; i++ {
+		require.NoError(t, get("/fail"))
+	}
+	assert.Equal(t, transport.BreakerClosed, breaker.State("/fail"))
+	require.NoError(t, get("/fail"))
+	assert.Equal(t, transport.BreakerOpen, breaker.State("/fail"))
+
+	// Open circuits fail fast; other paths are unaffected.
+	before := atomic.LoadInt32(&calls)
+	assert.ErrorIs(t, get("/fail"), astroerrors.ErrCircuitOpen)
+	assert.Equal(t, before, atomic.LoadInt32(&calls))
+	require.NoError(t, get("/ok"))
+
+	// After the cool-off a failed probe opens the circuit again...
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, transport.BreakerHalfOpen, breaker.State("/fail"))
+	require.NoError(t, get("/fail"))
+	assert.Equal(t, transport.BreakerOpen, breaker.State("/fail"))
+
+	// ...and a successful one closes it.
+	time.Sleep(60 * time.Millisecond)
+	atomic.StoreInt32(&healthy, 1)
+	require.NoError(t, get("/fail"))
+	assert.Equal(t, transport.BreakerClosed, breaker.State("/fail"))
+}
+
+func TestBreakerTransport_IgnoresCallerCancellation(t *testing.T) {
+	breaker := transport.NewBreaker(transport.BreakerConfig{MinRequests: 1})
+	client := &http.Client{Transport: &transport.BreakerTransport{Breaker: breaker}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://127.0.0.1:1/x", nil)
+	_, err := client.Do(req)
+	require.Error(t, err)
+	assert.Equal(t, transport.BreakerClosed, breaker.State("/x"))
+}
+
+func TestBreakerTransport_KeysByEndpoint(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	breaker := transport.NewBreaker(transport.BreakerConfig{MinRequests: 2, CoolOff: time.Minute})
+	client := &http.Client{Transport: &transport.BreakerTransport{Breaker: breaker}}
+	ctx := transport.WithEndpoint(context.Background(), "lunar.GetCalendar")
+	for _, year := range []string{"2024", "2025"} {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/lunar/calendar/"+year, nil)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, transport.BreakerOpen, breaker.State("lunar.GetCalendar"))
+	assert.Equal(t, transport.BreakerClosed, breaker.State("/lunar/calendar/2024"))
+}
@@ -0,0 +1,186 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A rule is one comma-separated entry of a `validate` struct tag, such as
+// "required", "max=78" or "oneof=placidus koch". Rules other than required
+// are only checked for non-empty values.
+//
+// Supported rules:
+//
+//	required     the value must not be the zero value
+//	min=N        numbers must be >= N; strings, slices and maps need at least N elements
+//	max=N        numbers must be <= N; strings, slices and maps may have at most N elements
+//	len=N        strings, slices and maps must have exactly N elements
+//	oneof=a b c  the value must be one of the space-separated options
+//	date         strings must be a calendar date in YYYY-MM-DD form
+//	latitude     numbers must be within [-90, 90]
+//	longitude    numbers must be within [-180, 180]
+//	place        values with a HasPlace method, or each element of a slice of
+//	             them, must report true; used for subjects whose birth place
+//	             the endpoint needs
+type rule struct {
+	name  string
+	param string
+}
+
+func parseRules(tag string) []rule {
+	if tag == "" {
+		return nil
+	}
+	var rules []rule
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(part, "=")
+		rules = append(rules, rule{name: name, param: param})
+	}
+	return rules
+}
+
+// check returns a message describing why v violates the rule, or "" if it
+// does not.
+func (r rule) check(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch r.name {
+	case "required":
+		return ""
+	case "min", "max":
+		limit, err := strconv.ParseFloat(r.param, 64)
+		if err != nil {
+			return r.malformed()
+		}
+		n, isLen, ok := measure(v)
+		if !ok {
+			return ""
+		}
+		if r.name == "min" && n < limit {
+			if isLen {
+				return fmt.Sprintf("must have at least %s elements", r.param)
+			}
+			return fmt.Sprintf("must be at least %s", r.param)
+		}
+		if r.name == "max" && n > limit {
+			if isLen {
+				return fmt.Sprintf("must have at most %s elements", r.param)
+			}
+			return fmt.Sprintf("must be at most %s", r.param)
+		}
+	case "len":
+		want, err := strconv.Atoi(r.param)
+		if err != nil {
+			return r.malformed()
+		}
+		switch v.Kind() {
+		case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+			if v.Len() != want {
+				return fmt.Sprintf("must have length %d", want)
+			}
+		}
+	case "oneof":
+		options := strings.Fields(r.param)
+		s, ok := scalarString(v)
+		if ok && !contains(options, s) {
+			return fmt.Sprintf("must be one of [%s]", strings.Join(options, " "))
+		}
+	case "date":
+		if v.Kind() == reflect.String {
+			if _, err := time.Parse(time.DateOnly, v.String()); err != nil {
+				return "must be a date in YYYY-MM-DD form"
+			}
+		}
+	case "latitude":
+		if n, isLen, ok := measure(v); ok && !isLen && (n < -90 || n > 90) {
+			return "must be a latitude between -90 and 90"
+		}
+	case "longitude":
+		if n, isLen, ok := measure(v); ok && !isLen && (n < -180 || n > 180) {
+			return "must be a longitude between -180 and 180"
+		}
+	case "place":
+		if !hasPlace(v) {
+			return "needs a place: city and country_code, or latitude and longitude"
+		}
+	default:
+		return r.malformed()
+	}
+	return ""
+}
+
+func (r rule) malformed() string {
+	return fmt.Sprintf("has unsupported validation rule %q", r.name+"="+r.param)
+}
+
+// placer is implemented by shared.Subject and shared.BirthData.
+type placer interface {
+	HasPlace() bool
+}
+
+// hasPlace reports whether v, or every element of v if it is a slice, has a
+// place. Values without a HasPlace method pass.
+func hasPlace(v reflect.Value) bool {
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		for i := 0; i < v.Len(); i++ {
+			if !hasPlace(v.Index(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	if p, ok := v.Interface().(placer); ok {
+		return p.HasPlace()
+	}
+	return true
+}
+
+// measure returns the numeric value of v, or its length for strings, slices
+// and maps (isLen is then true).
+func measure(v reflect.Value) (n float64, isLen bool, ok bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), false, true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), false, true
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), true, true
+	}
+	return 0, false, false
+}
+
+// scalarString formats strings and numbers for comparison with oneof options.
+func scalarString(v reflect.Value) (string, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	}
+	return "", false
+}
+
+func contains(options []string, s string) bool {
+	for _, o := range options {
+		if o == s {
+			return true
+		}
+	}
+	return false
+}
@@ -3,9 +3,12 @@
 package validator
 
 import (
-	"fmt"
+	"errors"
 	"reflect"
+	"strconv"
 	"strings"
+
+	astroerrors "github.com/astro-api/astroapi-go/errors"
 )
 
 // Validatable is an optional interface that types can implement to
@@ -14,60 +17,157 @@ type Validatable interface {
 	Validate() error
 }
 
-// Validate checks params for fields tagged with `validate:"required"`.
-// It also calls params.Validate() if the Validatable interface is implemented.
+// Validate checks params against their `validate` struct tags (see rule for
+// the supported rules), descending into nested structs, pointers and slices.
+// Unset optional fields are skipped entirely. It also calls Validate() on
+// every value that implements Validatable.
 // params may be nil or a pointer to nil — both are safe.
+//
+// All violations are collected and returned together as an
+// *errors.ValidationError whose paths use the JSON field names, e.g.
+// "subject1.birth_data.year".
 func Validate(params any) error {
 	if params == nil {
 		return nil
 	}
+	var w walker
+	w.walk(reflect.ValueOf(params), "")
+	if len(w.violations) == 0 {
+		return nil
+	}
+	return &astroerrors.ValidationError{Violations: w.violations}
+}
+
+type walker struct {
+	violations []astroerrors.Violation
+}
+
+func (w *walker) add(path, rule, message string) {
+	w.violations = append(w.violations, astroerrors.Violation{Path: path, Rule: rule, Message: message})
+}
 
-	v := reflect.ValueOf(params)
-	// Dereference pointer(s).
-	for v.Kind() == reflect.Ptr {
+// walk validates v, which is found at path.
+func (w *walker) walk(v reflect.Value, path string) {
+	// Dereference pointer(s) and interfaces.
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
 		if v.IsNil() {
-			return nil
+			return
 		}
 		v = v.Elem()
 	}
 
-	if v.Kind() != reflect.Struct {
-		return nil
-	}
-
-	if err := checkRequired(v); err != nil {
-		return err
-	}
-
-	if val, ok := params.(Validatable); ok {
-		return val.Validate()
+	switch v.Kind() {
+	case reflect.Struct:
+		w.walkStruct(v, path)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			w.walk(v.Index(i), joinPath(path, strconv.Itoa(i)))
+		}
+		return
+	default:
+		return
 	}
 
-	return nil
+	w.custom(v, path)
 }
 
-func checkRequired(v reflect.Value) error {
+func (w *walker) walkStruct(v reflect.Value, path string) {
 	t := v.Type()
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
 		val := v.Field(i)
+		fieldPath := joinPath(path, fieldName(field))
 
-		tag := field.Tag.Get("validate")
-		if !hasRequired(tag) {
+		rules := parseRules(field.Tag.Get("validate"))
+		empty := isEmpty(val)
+		if inner, set, ok := unwrapOptional(val); ok {
+			// An apijson.Field is checked by its value; an explicit zero
+			// counts as set.
+			val, empty = inner, !set
+		}
+		if empty {
+			// Empty optional fields, including unset nested structs, are
+			// not checked any further.
+			if hasRule(rules, "required") {
+				w.add(fieldPath, "required", "is required")
+			}
 			continue
 		}
+		for _, r := range rules {
+			if msg := r.check(val); msg != "" {
+				w.add(fieldPath, r.name, msg)
+			}
+		}
+		w.walk(val, fieldPath)
+	}
+}
 
-		if isEmpty(val) {
-			name := fieldName(field)
-			return fmt.Errorf("field %q is required", name)
+// custom runs the Validatable hook of the struct v, if any. Violations it
+// reports are re-rooted at path; other errors become a single violation.
+func (w *walker) custom(v reflect.Value, path string) {
+	var val Validatable
+	if v.CanInterface() {
+		val, _ = v.Interface().(Validatable)
+	}
+	if val == nil && v.CanAddr() && v.Addr().CanInterface() {
+		val, _ = v.Addr().Interface().(Validatable)
+	}
+	if val == nil {
+		return
+	}
+	err := val.Validate()
+	if err == nil {
+		return
+	}
+	var verr *astroerrors.ValidationError
+	if errors.As(err, &verr) {
+		for _, vi := range verr.Violations {
+			w.add(joinPath(path, vi.Path), vi.Rule, vi.Message)
 		}
+		return
+	}
+	w.add(path, "invalid", err.Error())
+}
+
+func joinPath(prefix, name string) string {
+	switch {
+	case prefix == "":
+		return name
+	case name == "":
+		return prefix
+	}
+	return prefix + "." + name
+}
+
+// optional is implemented by apijson.Field.
+type optional interface {
+	IsPresent() bool
+	IsNull() bool
+}
+
+// unwrapOptional returns the Value of an apijson.Field and whether it holds a
+// non-null value. ok is false if v is not a Field.
+func unwrapOptional(v reflect.Value) (inner reflect.Value, set bool, ok bool) {
+	if v.Kind() != reflect.Struct || !v.CanInterface() {
+		return v, false, false
+	}
+	o, isOpt := v.Interface().(optional)
+	if !isOpt {
+		return v, false, false
+	}
+	inner = v.FieldByName("Value")
+	if !inner.IsValid() {
+		return v, false, false
 	}
-	return nil
+	return inner, o.IsPresent() && !o.IsNull(), true
 }
 
-func hasRequired(tag string) bool {
-	for _, part := range strings.Split(tag, ",") {
-		if strings.TrimSpace(part) == "required" {
+func hasRule(rules []rule, name string) bool {
+	for _, r := range rules {
+		if r.name == name {
 			return true
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	astroerrors "github.com/astro-api/astroapi-go/errors"
 	"github.com/astro-api/astroapi-go/internal/validator"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -93,3 +94,128 @@ func TestValidate_NonStruct(t *testing.T) {
 	err := validator.Validate("just a string")
 	assert.NoError(t, err)
 }
+
+type nestedParams struct {
+	Subject1 nestedSubject   `json:"subject1" validate:"required"`
+	Subject2 *nestedSubject  `json:"subject2"`
+	Others   []nestedSubject `json:"others"`
+}
+
+type nestedSubject struct {
+	Name      string          `json:"name" validate:"required"`
+	BirthData nestedBirthData `json:"birth_data"`
+}
+
+type nestedBirthData struct {
+	Year int    `json:"year" validate:"required"`
+	City string `json:"city" validate:"required"`
+}
+
+func TestValidate_CollectsNestedViolations(t *testing.T) {
+	p := nestedParams{
+		Subject1: nestedSubject{BirthData: nestedBirthData{City: "London"}},
+		Subject2: &nestedSubject{Name: "Bob", BirthData: nestedBirthData{Year: 1985}},
+		Others:   []nestedSubject{{Name: "Eve", BirthData: nestedBirthData{Year: 1990}}},
+	}
+	err := validator.Validate(&p)
+	require.Error(t, err)
+
+	var verr *astroerrors.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.ErrorIs(t, err, astroerrors.ErrValidation)
+
+	var paths []string
+	for _, v := range verr.Violations {
+		assert.Equal(t, "required", v.Rule)
+		paths = append(paths, v.Path)
+	}
+	assert.Equal(t, []string{
+		"subject1.name",
+		"subject1.birth_data.year",
+		"subject2.birth_data.city",
+		"others.0.birth_data.city",
+	}, paths)
+	assert.Contains(t, err.Error(), "subject1.birth_data.year is required")
+}
+
+type customNested struct {
+	Inner customValidatable `json:"inner"`
+}
+
+func TestValidate_NestedCustomValidatable(t *testing.T) {
+	err := validator.Validate(customNested{Inner: customValidatable{Name: "bad"}})
+	var verr *astroerrors.ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Violations, 1)
+	assert.Equal(t, "inner", verr.Violations[0].Path)
+	assert.Equal(t, "invalid", verr.Violations[0].Rule)
+}
+
+type ruleParams struct {
+	Count     int      `json:"count" validate:"required,min=1,max=78"`
+	Tags      []string `json:"tags" validate:"max=2"`
+	Country   string   `json:"country" validate:"len=2"`
+	House     string   `json:"house" validate:"oneof=placidus koch"`
+	Start     string   `json:"start" validate:"date"`
+	Latitude  float64  `json:"latitude" validate:"latitude"`
+	Longitude float64  `json:"longitude" validate:"longitude"`
+}
+
+func TestValidate_Rules(t *testing.T) {
+	valid := ruleParams{
+		Count: 3, Tags: []string{"a"}, Country: "GB", House: "koch",
+		Start: "2024-02-29", Latitude: -33.9, Longitude: 151.2,
+	}
+	require.NoError(t, validator.Validate(valid))
+
+	// Empty optional fields are not checked.
+	require.NoError(t, validator.Validate(ruleParams{Count: 1}))
+
+	invalid := ruleParams{
+		Count: 500, Tags: []string{"a", "b", "c"}, Country: "GBR", House: "equal",
+		Start: "2023-02-29", Latitude: 91, Longitude: -180.5,
+	}
+	err := validator.Validate(invalid)
+	var verr *astroerrors.ValidationError
+	require.ErrorAs(t, err, &verr)
+
+	got := map[string]string{}
+	for _, v := range verr.Violations {
+		got[v.Path] = v.Rule
+	}
+	assert.Equal(t, map[string]string{
+		"count":     "max",
+		"tags":      "max",
+		"country":   "len",
+		"house":     "oneof",
+		"start":     "date",
+		"latitude":  "latitude",
+		"longitude": "longitude",
+	}, got)
+}
+
+func TestValidate_MinRule(t *testing.T) {
+	type params struct {
+		Subjects []string `json:"subjects" validate:"required,min=2"`
+	}
+	err := validator.Validate(params{Subjects: []string{"one"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "subjects must have at least 2 elements")
+}
+
+type placed struct{ nowhere bool }
+
+func (p placed) HasPlace() bool { return !p.nowhere }
+
+func TestValidate_PlaceRule(t *testing.T) {
+	type params struct {
+		Subject  placed   `json:"subject" validate:"place"`
+		Subjects []placed `json:"subjects" validate:"place"`
+	}
+	require.NoError(t, validator.Validate(params{Subject: placed{}, Subjects: []placed{{}}}))
+
+	err := validator.Validate(params{Subject: placed{nowhere: true}, Subjects: []placed{{}, {nowhere: true}}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "subject needs a place")
+	assert.Contains(t, err.Error(), "subjects needs a place")
+}
@@ -2,19 +2,60 @@
 package option
 
 import (
+	"fmt"
+	"log/slog"
 	"net/http"
+	"slices"
 	"time"
 
+	"github.com/astro-api/astroapi-go/cache"
+	"github.com/astro-api/astroapi-go/credentials"
+	"github.com/astro-api/astroapi-go/internal/form"
+	"github.com/astro-api/astroapi-go/internal/profile"
+	"github.com/astro-api/astroapi-go/internal/ratelimit"
 	"github.com/astro-api/astroapi-go/internal/requestconfig"
+	"github.com/astro-api/astroapi-go/internal/transport"
+	"github.com/astro-api/astroapi-go/shared"
+)
+
+// Jitter selects how the delay between retries is randomised.
+type Jitter = transport.Jitter
+
+const (
+	// JitterNone uses plain exponential backoff.
+	JitterNone = transport.JitterNone
+	// JitterFull waits a random duration between zero and the exponential backoff.
+	JitterFull = transport.JitterFull
+	// JitterDecorrelated waits a random duration between the initial delay and
+	// three times the previous delay.
+	JitterDecorrelated = transport.JitterDecorrelated
 )
 
 // RequestOption is a function that modifies a RequestConfig.
 type RequestOption func(*requestconfig.RequestConfig)
 
-// WithAPIKey sets the API key used for authentication.
+// WithAPIKey sets the API key used for authentication. It replaces a
+// provider set with WithCredentials.
 func WithAPIKey(key string) RequestOption {
 	return func(rc *requestconfig.RequestConfig) {
 		rc.APIKey = key
+		rc.Credentials = nil
+	}
+}
+
+// CredentialProvider supplies the API key for each request; see the
+// credentials package for implementations.
+type CredentialProvider = credentials.Provider
+
+// WithCredentials sets a provider that is asked for the API key on every
+// attempt, so keys can be rotated without restarting the process. It takes
+// precedence over ASTROLOGY_API_KEY and profile keys; a later WithAPIKey
+// replaces it.
+//
+//	option.WithCredentials(credentials.Rotating(primaryKey, secondaryKey))
+func WithCredentials(p CredentialProvider) RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.Credentials = p
 	}
 }
 
@@ -25,6 +66,33 @@ func WithBaseURL(url string) RequestOption {
 	}
 }
 
+// WithBaseURLs sets an ordered list of base URLs, such as a primary region
+// followed by fallbacks. Requests go to the first healthy endpoint and
+// retries move on to the next one. An endpoint is marked down after
+// consecutive 5xx responses or connection errors; see WithFailover.
+//
+//	option.WithBaseURLs("https://eu.example.com", "https://us.example.com")
+func WithBaseURLs(urls ...string) RequestOption {
+	endpoints, err := transport.NewEndpoints(urls)
+	return func(rc *requestconfig.RequestConfig) {
+		if err != nil {
+			rc.Err = fmt.Errorf("option.WithBaseURLs: %w", err)
+			return
+		}
+		rc.BaseURL = endpoints.Primary()
+		rc.Endpoints = endpoints
+	}
+}
+
+// WithFailover sets how many consecutive failures mark an endpoint set with
+// WithBaseURLs down (default: 3), and for how long (default: 30s).
+func WithFailover(threshold int, coolOff time.Duration) RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.FailoverThreshold = threshold
+		rc.FailoverCoolOff = coolOff
+	}
+}
+
 // WithHTTPClient sets a custom *http.Client.
 func WithHTTPClient(c *http.Client) RequestOption {
 	return func(rc *requestconfig.RequestConfig) {
@@ -46,6 +114,15 @@ func WithRetryDelay(d time.Duration) RequestOption {
 	}
 }
 
+// WithRetryJitter sets the jitter strategy applied to retry backoff (default: JitterFull).
+// A Retry-After or X-RateLimit-Reset header on the response is always treated
+// as a lower bound on the wait.
+func WithRetryJitter(j Jitter) RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.RetryJitter = j
+	}
+}
+
 // WithRetryableStatusCodes overrides which HTTP status codes trigger a retry.
 func WithRetryableStatusCodes(codes ...int) RequestOption {
 	return func(rc *requestconfig.RequestConfig) {
@@ -53,13 +130,132 @@ func WithRetryableStatusCodes(codes ...int) RequestOption {
 	}
 }
 
-// WithRequestTimeout sets the per-request timeout.
+// WithRequestTimeout sets the deadline for a whole call, including retries,
+// backoff and reading the response (default: 30s). Errors caused by it match
+// errors.ErrRequestTimeout.
 func WithRequestTimeout(d time.Duration) RequestOption {
 	return func(rc *requestconfig.RequestConfig) {
 		rc.RequestTimeout = d
 	}
 }
 
+// WithAttemptTimeout bounds each attempt, so that a slow attempt is retried
+// instead of using up the whole request timeout. Errors caused by it match
+// errors.ErrAttemptTimeout. Zero (the default) means no per-attempt limit.
+func WithAttemptTimeout(d time.Duration) RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.AttemptTimeout = d
+	}
+}
+
+// Hooks are callbacks for observing calls; see WithHooks.
+type Hooks = requestconfig.Hooks
+
+// Events passed to Hooks.
+type (
+	RequestEvent  = requestconfig.RequestEvent
+	AttemptEvent  = requestconfig.AttemptEvent
+	ResponseEvent = requestconfig.ResponseEvent
+	ErrorEvent    = requestconfig.ErrorEvent
+)
+
+// WithHooks sets callbacks that observe every call and each of its retry
+// attempts, without the SDK depending on a telemetry library. Each event
+// carries the endpoint name, such as "charts.GetNatal".
+//
+//	option.WithHooks(option.Hooks{
+//	    OnAttempt: func(e option.AttemptEvent) {
+//	        if e.Attempt > 1 {
+//	            retries.WithLabelValues(e.Endpoint).Inc()
+//	        }
+//	    },
+//	})
+func WithHooks(h Hooks) RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.Hooks = &h
+	}
+}
+
+// WithLogger logs every call to logger: finished calls at info level, failed
+// calls at error level, retries at warn level and, at debug level, each
+// attempt and the request and response headers and bodies. The
+// Authorization header is always redacted; see WithLogRedactPII for
+// personal data.
+func WithLogger(logger *slog.Logger) RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.Logger = logger
+	}
+}
+
+// WithLogRedactPII masks the name, email and notes of subjects and their
+// exact birth coordinates in bodies logged by WithLogger. Bodies that are not
+// JSON are not logged at all.
+func WithLogRedactPII() RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.LogRedactPII = true
+	}
+}
+
+// Middleware wraps the sending of a request; see WithMiddleware.
+type Middleware = requestconfig.Middleware
+
+// MiddlewareNext sends the request on down the chain.
+type MiddlewareNext = requestconfig.MiddlewareNext
+
+// WithMiddleware adds middlewares that run around the built-in retry and
+// auth transports, once per call. Client-wide middlewares run before
+// per-request ones, each group in the order added. A middleware may change
+// the request, inspect the response or return early without calling next.
+//
+//	option.WithMiddleware(func(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+//	    req.Header.Set("X-Tenant", tenant)
+//	    return next(req)
+//	})
+func WithMiddleware(middlewares ...Middleware) RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.Middlewares = append(rc.Middlewares, middlewares...)
+	}
+}
+
+// WithProfile loads the named profile from the config file (see
+// ProfileConfigPath) when passed to astroapi.NewClient. It takes precedence
+// over the ASTROLOGY_API_PROFILE environment variable. Settings from the
+// profile are overridden by environment variables and by explicit options.
+// If the profile cannot be loaded, every request returns the error.
+func WithProfile(name string) RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.Profile = name
+	}
+}
+
+// ProfileConfigPath returns the location of the profile config file:
+// $ASTROLOGY_API_CONFIG if set, otherwise astroapi/config.json in the
+// user config directory (e.g. ~/.config on Linux).
+func ProfileConfigPath() (string, error) { return profile.Path() }
+
+// WithDefaultAstrologyOptions sets chart options that are merged into the
+// Options of every request whose params have a *shared.AstrologyOptions
+// field. Fields set on the request win. Zero strings and slices count as
+// unset; Precision and the Include flags are shared.Field values, so an
+// explicit 0 or false on the request wins too.
+//
+//	option.WithDefaultAstrologyOptions(shared.AstrologyOptions{HouseSystem: "P", Language: "de"})
+func WithDefaultAstrologyOptions(o shared.AstrologyOptions) RequestOption {
+	o.ActivePoints = slices.Clone(o.ActivePoints)
+	return func(rc *requestconfig.RequestConfig) {
+		rc.DefaultAstrologyOptions = &o
+	}
+}
+
+// WithDefaultReportOptions sets report options that are merged into the
+// Options of every request whose params have a *shared.ReportOptions field.
+// Fields set on the request win.
+func WithDefaultReportOptions(o shared.ReportOptions) RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.DefaultReportOptions = &o
+	}
+}
+
 // WithHeader adds an extra header to every request.
 func WithHeader(key, value string) RequestOption {
 	return func(rc *requestconfig.RequestConfig) {
@@ -70,10 +266,133 @@ func WithHeader(key, value string) RequestOption {
 	}
 }
 
-// WithResponseInto stores the raw *http.Response into the given pointer after
-// a successful request. Useful for inspecting response headers.
+// QueryNesting selects how nested GET params are flattened into query keys.
+type QueryNesting = form.Nesting
+
+const (
+	// QueryBrackets writes nested keys as parent[child] (the default).
+	QueryBrackets = form.Brackets
+	// QueryDots writes nested keys as parent.child.
+	QueryDots = form.Dots
+)
+
+// WithQueryNesting sets how nested structs, maps and slices of structs in GET
+// params are flattened into query keys (default: QueryBrackets).
+func WithQueryNesting(n QueryNesting) RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.QueryNesting = n
+	}
+}
+
+// WithIdempotencyKey sets the Idempotency-Key header sent with POST requests.
+// By default a new random key is generated for every call and reused across
+// its retry attempts, so the API can deduplicate replays. Supply your own key
+// per request to make a call safe to repeat across process restarts.
+//
+// Pass it per request only: a client built with it, by NewClient or
+// WithOptions, fails every call, as all of its POSTs would share the key.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.IdempotencyKey = key
+	}
+}
+
+// ResponseMeta describes how a single call was served: status, request ID,
+// rate-limit headers, attempt count, latencies and the raw body.
+type ResponseMeta = requestconfig.ResponseMeta
+
+// WithResponseMeta fills meta with response metadata once the call returns,
+// whether it succeeded or failed. Pass it per request:
+//
+//	var meta option.ResponseMeta
+//	chart, err := client.Charts.GetNatal(ctx, params, option.WithResponseMeta(&meta))
+//	log.Println(meta.RequestID, meta.Attempts, meta.Latency)
+func WithResponseMeta(meta *ResponseMeta) RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.ResponseMeta = meta
+	}
+}
+
+// WithResponseInto stores the raw *http.Response into the given pointer once
+// the request completes. The body has been read by then and is replaced with
+// an in-memory copy, so it can still be read. Useful for inspecting response headers.
 func WithResponseInto(resp **http.Response) RequestOption {
 	return func(rc *requestconfig.RequestConfig) {
 		rc.ResponseInto = resp
 	}
 }
+
+// WithRateLimit limits outgoing requests to rps requests per second with
+// bursts of up to burst requests. The limiter slows down after 429 responses
+// and waits for the window to reset when X-RateLimit-Remaining reaches zero.
+// Set it on the client so all category clients share one limiter; rps <= 0
+// disables client-side rate limiting.
+func WithRateLimit(rps float64, burst int) RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		if rps <= 0 {
+			rc.RateLimiter = nil
+			return
+		}
+		rc.RateLimiter = ratelimit.New(rps, burst)
+	}
+}
+
+// CircuitBreaker configures WithCircuitBreaker. Zero fields take the
+// defaults: a failure ratio of 0.5 over at least 10 calls in a one-minute
+// window, a 30s cool-off and one probe call at a time while half-open.
+type CircuitBreaker = transport.BreakerConfig
+
+// WithCircuitBreaker adds a circuit breaker around the retrying transport.
+// It tracks the failure ratio of each endpoint method, such as
+// tarot.GetCard, across all of its URL paths; connection errors, 5xx
+// responses and request timeouts count as failures. Once the ratio is
+// reached the circuit opens and calls fail fast with
+// errors.ErrCircuitOpen until the cool-off has passed, after which probe
+// calls decide whether it closes again. Set it on the client so all
+// category clients share the breaker.
+//
+//	option.WithCircuitBreaker(option.CircuitBreaker{FailureRatio: 0.5, CoolOff: 10 * time.Second})
+func WithCircuitBreaker(c CircuitBreaker) RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.Breaker = transport.NewBreaker(c)
+	}
+}
+
+// WithCache enables response caching with the given Cache, for example
+// cache.NewLRU(1000). Only requests with a positive cache TTL are cached; see
+// WithCacheTTL.
+func WithCache(c cache.Cache) RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.Cache = c
+	}
+}
+
+// WithCacheTTL sets how long successful responses are cached. Used as a
+// client option it applies to every endpoint without a TTL of its own; used
+// per request it overrides the endpoint's TTL. Zero disables caching.
+func WithCacheTTL(d time.Duration) RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.CacheTTL = d
+	}
+}
+
+// WithStrictDecoding makes response decoding strict:
+//   - a "data"/"result" payload that does not match the result type is
+//     reported as an *errors.DecodeError with the JSON path of the mismatch,
+//     instead of falling back to decoding the whole body;
+//   - a 2xx response whose body has "success": false is reported as an
+//     *errors.AstrologyError.
+func WithStrictDecoding() RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.StrictDecoding = true
+	}
+}
+
+// WithDisallowUnknownFields enables strict decoding (see WithStrictDecoding)
+// and additionally rejects payload fields that the result type does not declare.
+func WithDisallowUnknownFields() RequestOption {
+	return func(rc *requestconfig.RequestConfig) {
+		rc.StrictDecoding = true
+		rc.DisallowUnknownFields = true
+	}
+}
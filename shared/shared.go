@@ -2,21 +2,63 @@
 // Astrology API categories.
 package shared
 
-// BirthData represents the birth information for a person.
+import "github.com/astro-api/astroapi-go/internal/apijson"
+
+// Field is an optional request value that tells an explicit zero apart from
+// an absent one. Create it with F; the zero Field is left out of the request.
+type Field[T any] = apijson.Field[T]
+
+// F returns a Field holding v. It is sent even when v is the zero value.
+func F[T any](v T) Field[T] { return apijson.F(v) }
+
+// BirthData represents the birth information for a person. The date, time
+// and coordinate fields are Fields, so that midnight, the prime meridian and
+// the equator are sent as explicit zeros:
+//
+//	shared.BirthData{
+//		Year: 1990, Month: shared.F(5), Day: shared.F(11),
+//		Hour: shared.F(0), Minute: shared.F(0),
+//		Latitude: shared.F(51.48), Longitude: shared.F(0.0),
+//	}
 type BirthData struct {
-	Year        int     `json:"year"`
-	Month       int     `json:"month,omitempty"`
-	Day         int     `json:"day,omitempty"`
-	Hour        int     `json:"hour,omitempty"`
-	Minute      int     `json:"minute,omitempty"`
-	Second      int     `json:"second,omitempty"`
-	City        string  `json:"city,omitempty"`
-	CountryCode string  `json:"country_code,omitempty"`
-	Latitude    float64 `json:"latitude,omitempty"`
-	Longitude   float64 `json:"longitude,omitempty"`
-	Timezone    string  `json:"timezone,omitempty"`
+	Year        int            `json:"year"`
+	Month       Field[int]     `json:"month,omitzero" validate:"min=1,max=12"`
+	Day         Field[int]     `json:"day,omitzero" validate:"min=1,max=31"`
+	Hour        Field[int]     `json:"hour,omitzero" validate:"min=0,max=23"`
+	Minute      Field[int]     `json:"minute,omitzero" validate:"min=0,max=59"`
+	Second      Field[int]     `json:"second,omitzero" validate:"min=0,max=59"`
+	City        string         `json:"city,omitempty"`
+	CountryCode string         `json:"country_code,omitempty" validate:"len=2"`
+	Latitude    Field[float64] `json:"latitude,omitzero" validate:"latitude"`
+	Longitude   Field[float64] `json:"longitude,omitzero" validate:"longitude"`
+	Timezone    string         `json:"timezone,omitempty"`
+	// TimeUnknown marks a birth whose time of day is not known. Hour, Minute
+	// and Second must then be left unset.
+	TimeUnknown bool `json:"time_unknown,omitempty"`
+	// Rodden rates the reliability of the birth data.
+	Rodden RoddenRating `json:"rodden_rating,omitempty" validate:"oneof=AA A B C DD X XX"`
 }
 
+// RoddenRating is the Rodden data-quality rating of a birth record.
+type RoddenRating string
+
+const (
+	// RoddenAA: from a birth certificate or other official record.
+	RoddenAA RoddenRating = "AA"
+	// RoddenA: quoted by the person, family or friends.
+	RoddenA RoddenRating = "A"
+	// RoddenB: from a biography or autobiography.
+	RoddenB RoddenRating = "B"
+	// RoddenC: caution, the source is unknown or the time was rectified.
+	RoddenC RoddenRating = "C"
+	// RoddenDD: dirty data, with conflicting sources.
+	RoddenDD RoddenRating = "DD"
+	// RoddenX: the date is known but the time is not.
+	RoddenX RoddenRating = "X"
+	// RoddenXX: neither date nor time is reliably known.
+	RoddenXX RoddenRating = "XX"
+)
+
 // Subject represents a person for whom calculations are performed.
 type Subject struct {
 	Name      string    `json:"name,omitempty"`
@@ -26,38 +68,42 @@ type Subject struct {
 }
 
 // DateTimeLocation represents a point in time at a geographic location.
+// Second and the coordinates are Fields, as in BirthData, so that the equator
+// and the prime meridian are sent as explicit zeros.
 type DateTimeLocation struct {
-	Year        int     `json:"year"`
-	Month       int     `json:"month"`
-	Day         int     `json:"day"`
-	Hour        int     `json:"hour"`
-	Minute      int     `json:"minute"`
-	Second      int     `json:"second,omitempty"`
-	City        string  `json:"city,omitempty"`
-	CountryCode string  `json:"country_code,omitempty"`
-	Latitude    float64 `json:"latitude,omitempty"`
-	Longitude   float64 `json:"longitude,omitempty"`
-	Timezone    string  `json:"timezone,omitempty"`
+	Year        int            `json:"year"`
+	Month       int            `json:"month" validate:"min=1,max=12"`
+	Day         int            `json:"day" validate:"min=1,max=31"`
+	Hour        int            `json:"hour" validate:"min=0,max=23"`
+	Minute      int            `json:"minute" validate:"min=0,max=59"`
+	Second      Field[int]     `json:"second,omitzero" validate:"min=0,max=59"`
+	City        string         `json:"city,omitempty"`
+	CountryCode string         `json:"country_code,omitempty" validate:"len=2"`
+	Latitude    Field[float64] `json:"latitude,omitzero" validate:"latitude"`
+	Longitude   Field[float64] `json:"longitude,omitzero" validate:"longitude"`
+	Timezone    string         `json:"timezone,omitempty"`
 }
 
-// DateRange represents a start and end date in ISO 8601 format (YYYY-MM-DD).
+// DateRange represents a start and end date, sent in ISO 8601 format (YYYY-MM-DD).
 type DateRange struct {
-	Start string `json:"start"`
-	End   string `json:"end"`
+	Start Date `json:"start" validate:"required"`
+	End   Date `json:"end" validate:"required"`
 }
 
 // AstrologyOptions provides common configuration for chart calculations.
+// Precision and the Include flags are Fields so that an explicit 0 or false
+// is sent, and wins over a client-wide default.
 type AstrologyOptions struct {
-	HouseSystem             string   `json:"house_system,omitempty"`
-	ZodiacType              string   `json:"zodiac_type,omitempty"`
-	ActivePoints            []string `json:"active_points,omitempty"`
-	Precision               int      `json:"precision,omitempty"`
-	Language                string   `json:"language,omitempty"`
-	Tradition               string   `json:"tradition,omitempty"`
-	Perspective             string   `json:"perspective,omitempty"`
-	DetailLevel             string   `json:"detail_level,omitempty"`
-	IncludeInterpretations  bool     `json:"include_interpretations,omitempty"`
-	IncludeRawData          bool     `json:"include_raw_data,omitempty"`
+	HouseSystem            string      `json:"house_system,omitempty"`
+	ZodiacType             string      `json:"zodiac_type,omitempty"`
+	ActivePoints           []string    `json:"active_points,omitempty"`
+	Precision              Field[int]  `json:"precision,omitzero"`
+	Language               string      `json:"language,omitempty"`
+	Tradition              string      `json:"tradition,omitempty"`
+	Perspective            string      `json:"perspective,omitempty"`
+	DetailLevel            string      `json:"detail_level,omitempty"`
+	IncludeInterpretations Field[bool] `json:"include_interpretations,omitzero"`
+	IncludeRawData         Field[bool] `json:"include_raw_data,omitzero"`
 }
 
 // ReportOptions contains options for text interpretation reports.
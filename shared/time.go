@@ -0,0 +1,203 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	astroerrors "github.com/astro-api/astroapi-go/errors"
+)
+
+// Date is a civil calendar date without a time of day or time zone. It is
+// sent as a "YYYY-MM-DD" string; the zero Date is left out of requests
+// when the field is tagged omitzero.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate returns the Date for the given year, month and day. The values are
+// not normalised; use Validate to check that the date exists.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// DateOf returns the date of t in t's location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// ParseDate parses a date in "YYYY-MM-DD" form.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("parsing date %q: %w", s, err)
+	}
+	return DateOf(t), nil
+}
+
+// String returns the date in "YYYY-MM-DD" form.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+// IsZero reports whether d is the zero Date.
+func (d Date) IsZero() bool { return d == Date{} }
+
+// Time returns midnight at the start of d in loc.
+func (d Date) Time(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// Before reports whether d is before other.
+func (d Date) Before(other Date) bool { return d.Time(time.UTC).Before(other.Time(time.UTC)) }
+
+// After reports whether d is after other.
+func (d Date) After(other Date) bool { return other.Before(d) }
+
+// Validate checks that d exists in the calendar.
+func (d Date) Validate() error {
+	if d.IsZero() {
+		return nil
+	}
+	if d.Month < time.January || d.Month > time.December || d.Day < 1 || d.Day > daysIn(d.Year, d.Month) {
+		return &astroerrors.ValidationError{Violations: []astroerrors.Violation{
+			{Rule: "date", Message: fmt.Sprintf("%s is not a calendar date", d)},
+		}}
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Date) MarshalText() ([]byte, error) { return []byte(d.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Date) UnmarshalText(data []byte) error {
+	parsed, err := ParseDate(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Date) MarshalJSON() ([]byte, error) { return json.Marshal(d.String()) }
+
+// UnmarshalJSON implements json.Unmarshaler. null leaves d unchanged.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// NewDateRange returns the range of dates from the date of start to the date
+// of end, each in its own location.
+func NewDateRange(start, end time.Time) DateRange {
+	return DateRange{Start: DateOf(start), End: DateOf(end)}
+}
+
+// NewBirthData returns birth data for the wall-clock time of t in a city.
+// Timezone is set from t's location unless it is time.Local.
+func NewBirthData(t time.Time, city, countryCode string) BirthData {
+	b := birthDataOf(t)
+	b.City, b.CountryCode = city, countryCode
+	return b
+}
+
+// NewBirthDataAt returns birth data for the wall-clock time of t at the
+// given coordinates. Timezone is set from t's location unless it is
+// time.Local.
+func NewBirthDataAt(t time.Time, latitude, longitude float64) BirthData {
+	b := birthDataOf(t)
+	b.Latitude, b.Longitude = F(latitude), F(longitude)
+	return b
+}
+
+func birthDataOf(t time.Time) BirthData {
+	return BirthData{
+		Year:     t.Year(),
+		Month:    F(int(t.Month())),
+		Day:      F(t.Day()),
+		Hour:     F(t.Hour()),
+		Minute:   F(t.Minute()),
+		Second:   F(t.Second()),
+		Timezone: zoneName(t),
+	}
+}
+
+// Time returns the birth moment in Timezone, or in UTC if Timezone is
+// empty. Unset time fields count as zero, so a birth with TimeUnknown
+// yields midnight.
+func (b BirthData) Time() (time.Time, error) {
+	loc, err := location(b.Timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(b.Year, time.Month(b.Month.Value), b.Day.Value,
+		b.Hour.Value, b.Minute.Value, b.Second.Value, 0, loc), nil
+}
+
+// NewDateTimeLocation returns the wall-clock time of t in a city.
+// Timezone is set from t's location unless it is time.Local.
+func NewDateTimeLocation(t time.Time, city, countryCode string) DateTimeLocation {
+	d := dateTimeLocationOf(t)
+	d.City, d.CountryCode = city, countryCode
+	return d
+}
+
+// NewDateTimeLocationAt returns the wall-clock time of t at the given
+// coordinates. Timezone is set from t's location unless it is time.Local.
+func NewDateTimeLocationAt(t time.Time, latitude, longitude float64) DateTimeLocation {
+	d := dateTimeLocationOf(t)
+	d.Latitude, d.Longitude = F(latitude), F(longitude)
+	return d
+}
+
+func dateTimeLocationOf(t time.Time) DateTimeLocation {
+	return DateTimeLocation{
+		Year:     t.Year(),
+		Month:    int(t.Month()),
+		Day:      t.Day(),
+		Hour:     t.Hour(),
+		Minute:   t.Minute(),
+		Second:   F(t.Second()),
+		Timezone: zoneName(t),
+	}
+}
+
+// Time returns the moment in Timezone, or in UTC if Timezone is empty.
+func (d DateTimeLocation) Time() (time.Time, error) {
+	loc, err := location(d.Timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(d.Year, time.Month(d.Month), d.Day, d.Hour, d.Minute, d.Second.Value, 0, loc), nil
+}
+
+// zoneName returns the IANA name of t's location. time.Local has no
+// portable IANA name, so it yields "".
+func zoneName(t time.Time) string {
+	if t.Location() == time.Local {
+		return ""
+	}
+	return t.Location().String()
+}
+
+func location(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("loading timezone %q: %w", timezone, err)
+	}
+	return loc, nil
+}
@@ -0,0 +1,117 @@
+package shared_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/astro-api/astroapi-go/internal/validator"
+	"github.com/astro-api/astroapi-go/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDate_JSONRoundTrip(t *testing.T) {
+	d := shared.NewDate(2024, time.February, 29)
+	data, err := json.Marshal(d)
+	require.NoError(t, err)
+	assert.Equal(t, `"2024-02-29"`, string(data))
+
+	var got shared.Date
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, d, got)
+
+	assert.Error(t, json.Unmarshal([]byte(`"29/02/2024"`), &got))
+}
+
+func TestDate_OmitZero(t *testing.T) {
+	type params struct {
+		Date shared.Date `json:"date,omitzero"`
+	}
+	data, err := json.Marshal(params{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(data))
+}
+
+func TestDate_TimeRoundTrip(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	// 23:30 UTC is already the next day in Tokyo.
+	ts := time.Date(2024, 3, 10, 23, 30, 0, 0, time.UTC).In(tokyo)
+	d := shared.DateOf(ts)
+	assert.Equal(t, "2024-03-11", d.String())
+	assert.Equal(t, time.Date(2024, 3, 11, 0, 0, 0, 0, tokyo), d.Time(tokyo))
+
+	parsed, err := shared.ParseDate(d.String())
+	require.NoError(t, err)
+	assert.Equal(t, d, parsed)
+}
+
+func TestDate_Validate(t *testing.T) {
+	assert.NoError(t, shared.NewDate(2000, 2, 29).Validate())
+	assert.Error(t, shared.NewDate(2100, 2, 29).Validate())
+	assert.Error(t, shared.NewDate(2024, 13, 1).Validate())
+}
+
+func TestNewDateRange(t *testing.T) {
+	r := shared.NewDateRange(time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 8, 0, 0, 0, time.UTC))
+	data, err := json.Marshal(r)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"start":"2024-01-01","end":"2024-01-31"}`, string(data))
+	require.NoError(t, validator.Validate(r))
+}
+
+func TestBirthData_TimeRoundTrip(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	born := time.Date(1990, 5, 11, 0, 5, 30, 0, ny)
+
+	b := shared.NewBirthData(born, "New York", "US")
+	assert.Equal(t, "America/New_York", b.Timezone)
+	assert.Equal(t, shared.F(0), b.Hour, "midnight is an explicit zero")
+	require.NoError(t, validator.Validate(b))
+
+	got, err := b.Time()
+	require.NoError(t, err)
+	assert.True(t, born.Equal(got))
+
+	at := shared.NewBirthDataAt(born.UTC(), 51.48, 0)
+	assert.Equal(t, "UTC", at.Timezone)
+	assert.Equal(t, shared.F(0.0), at.Longitude)
+	got, err = at.Time()
+	require.NoError(t, err)
+	assert.True(t, born.Equal(got))
+}
+
+func TestDateTimeLocation_TimeRoundTrip(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	ts := time.Date(2024, 7, 1, 12, 0, 0, 0, paris)
+
+	d := shared.NewDateTimeLocation(ts, "Paris", "FR")
+	require.NoError(t, validator.Validate(d))
+	got, err := d.Time()
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(got))
+
+	d.Timezone = "Nowhere/Special"
+	_, err = d.Time()
+	assert.Error(t, err)
+}
+
+func TestNewDateTimeLocationAt_ExplicitZeros(t *testing.T) {
+	// Greenwich, on the prime meridian, at midnight.
+	d := shared.NewDateTimeLocationAt(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), 51.48, 0)
+	require.NoError(t, validator.Validate(d))
+
+	data, err := json.Marshal(d)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"year":2024,"month":3,"day":1,"hour":0,"minute":0,"second":0,
+		"latitude":51.48,"longitude":0,"timezone":"UTC"}`, string(data))
+}
+
+func TestBirthData_Time_LocalHasNoTimezone(t *testing.T) {
+	b := shared.NewBirthDataAt(time.Date(1990, 5, 11, 14, 30, 0, 0, time.Local), 10, 20)
+	assert.Empty(t, b.Timezone)
+}
@@ -0,0 +1,138 @@
+package shared
+
+import (
+	"fmt"
+	"time"
+	_ "time/tzdata" // Timezone validation must not depend on the host's zoneinfo.
+
+	astroerrors "github.com/astro-api/astroapi-go/errors"
+)
+
+// Validate checks that the birth date exists in the calendar, that
+// Latitude and Longitude are given together, that Timezone, if set, is a
+// valid IANA zone and that no time of day is set when TimeUnknown is. The
+// place of birth is not required here, as some endpoints, such as
+// numerology, only need the date; params of endpoints that need it mark the
+// subject with the "place" validate rule. Field ranges are checked by the
+// struct tags.
+// Violations are reported as an *errors.ValidationError with paths relative
+// to the BirthData.
+func (b BirthData) Validate() error {
+	vs := checkDate(b.Year, b.Month.Value, b.Day.Value)
+	if b.TimeUnknown {
+		for i, f := range []Field[int]{b.Hour, b.Minute, b.Second} {
+			if f.IsPresent() {
+				vs = append(vs, astroerrors.Violation{
+					Path:    [...]string{"hour", "minute", "second"}[i],
+					Rule:    "time_unknown",
+					Message: "must be unset when time_unknown is set",
+				})
+			}
+		}
+	}
+	vs = append(vs, checkCoords(b.Latitude.IsPresent(), b.Longitude.IsPresent())...)
+	vs = append(vs, checkTimezone(b.Timezone)...)
+	return validationError(vs)
+}
+
+// Validate checks that the date exists in the calendar, with Month and Day
+// set, that the place is given either as City and CountryCode or as
+// coordinates, and that Timezone, if set, is a valid IANA zone.
+func (d DateTimeLocation) Validate() error {
+	vs := checkDate(d.Year, d.Month, d.Day)
+	if d.Month == 0 {
+		vs = append(vs, astroerrors.Violation{Path: "month", Rule: "required", Message: "is required"})
+	}
+	if d.Day == 0 {
+		vs = append(vs, astroerrors.Violation{Path: "day", Rule: "required", Message: "is required"})
+	}
+	vs = append(vs, checkCoords(d.Latitude.IsPresent(), d.Longitude.IsPresent())...)
+	if !hasPlace(d.City, d.CountryCode, d.Latitude, d.Longitude) {
+		vs = append(vs, astroerrors.Violation{
+			Rule:    "location",
+			Message: "needs city and country_code, or latitude and longitude",
+		})
+	}
+	vs = append(vs, checkTimezone(d.Timezone)...)
+	return validationError(vs)
+}
+
+// HasPlace reports whether the place of birth is given, either as City and
+// CountryCode or as both coordinates.
+func (b BirthData) HasPlace() bool {
+	return hasPlace(b.City, b.CountryCode, b.Latitude, b.Longitude)
+}
+
+// HasPlace reports whether the subject's place of birth is given.
+func (s Subject) HasPlace() bool { return s.BirthData.HasPlace() }
+
+func hasPlace(city, countryCode string, lat, lon Field[float64]) bool {
+	return city != "" && countryCode != "" || lat.IsPresent() && lon.IsPresent()
+}
+
+// Validate checks that Start is not after End. Each date is checked on its
+// own by Date.Validate.
+func (r DateRange) Validate() error {
+	if r.Start.IsZero() || r.End.IsZero() || !r.Start.After(r.End) {
+		return nil
+	}
+	return &astroerrors.ValidationError{Violations: []astroerrors.Violation{
+		{Path: "start", Rule: "range", Message: "must not be after end"},
+	}}
+}
+
+func checkDate(year, month, day int) []astroerrors.Violation {
+	var vs []astroerrors.Violation
+	if year == 0 {
+		vs = append(vs, astroerrors.Violation{Path: "year", Rule: "required", Message: "is required"})
+	}
+	// Out-of-range months and days are reported by the struct tags; a zero
+	// day means the day is unknown.
+	if month >= 1 && month <= 12 && day >= 1 && day <= 31 {
+		if last := daysIn(year, time.Month(month)); day > last {
+			vs = append(vs, astroerrors.Violation{
+				Path:    "day",
+				Rule:    "date",
+				Message: fmt.Sprintf("must be at most %d in %04d-%02d", last, year, month),
+			})
+		}
+	}
+	return vs
+}
+
+// checkCoords reports a latitude given without a longitude, or vice versa.
+func checkCoords(hasLat, hasLon bool) []astroerrors.Violation {
+	if hasLat == hasLon {
+		return nil
+	}
+	return []astroerrors.Violation{{
+		Rule:    "coordinates",
+		Message: "needs both latitude and longitude",
+	}}
+}
+
+func checkTimezone(timezone string) []astroerrors.Violation {
+	if timezone == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return []astroerrors.Violation{{
+			Path:    "timezone",
+			Rule:    "timezone",
+			Message: fmt.Sprintf("%q is not an IANA time zone", timezone),
+		}}
+	}
+	return nil
+}
+
+func validationError(vs []astroerrors.Violation) error {
+	if len(vs) == 0 {
+		return nil
+	}
+	return &astroerrors.ValidationError{Violations: vs}
+}
+
+// daysIn returns the number of days in the given month, honouring leap years.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,149 @@
+package shared_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	astroerrors "github.com/astro-api/astroapi-go/errors"
+	"github.com/astro-api/astroapi-go/internal/validator"
+	"github.com/astro-api/astroapi-go/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func violations(t *testing.T, err error) map[string]string {
+	t.Helper()
+	var verr *astroerrors.ValidationError
+	require.ErrorAs(t, err, &verr)
+	got := map[string]string{}
+	for _, v := range verr.Violations {
+		got[v.Path] = v.Rule
+	}
+	return got
+}
+
+func london() shared.BirthData {
+	return shared.BirthData{
+		Year: 1990, Month: shared.F(5), Day: shared.F(11),
+		Hour: shared.F(14), Minute: shared.F(30),
+		City: "London", CountryCode: "GB",
+	}
+}
+
+func TestBirthData_Validate_OK(t *testing.T) {
+	require.NoError(t, london().Validate())
+
+	coords := shared.BirthData{
+		Year: 2000, Month: shared.F(2), Day: shared.F(29),
+		Latitude: shared.F(51.5), Longitude: shared.F(-0.12), Timezone: "Europe/London",
+	}
+	require.NoError(t, coords.Validate())
+}
+
+func TestBirthData_Validate_LeapYear(t *testing.T) {
+	b := london()
+	b.Year, b.Month, b.Day = 1900, shared.F(2), shared.F(29)
+	assert.Equal(t, map[string]string{"day": "date"}, violations(t, b.Validate()))
+
+	b.Year = 2024
+	assert.NoError(t, b.Validate())
+}
+
+func TestBirthData_Validate_CoordinatesAndTimezone(t *testing.T) {
+	b := shared.BirthData{Year: 1990, Month: shared.F(4), Day: shared.F(31), Latitude: shared.F(51.5), Timezone: "Mars/Olympus"}
+	assert.Equal(t, map[string]string{
+		"day":      "date",
+		"":         "coordinates",
+		"timezone": "timezone",
+	}, violations(t, b.Validate()))
+}
+
+func TestBirthData_Validate_DateOnly(t *testing.T) {
+	// Numerology and similar endpoints need no place of birth.
+	b := shared.BirthData{Year: 1815, Month: shared.F(12), Day: shared.F(10)}
+	require.NoError(t, b.Validate())
+
+	b.Latitude, b.Longitude = shared.F(0.0), shared.F(0.0)
+	require.NoError(t, b.Validate())
+}
+
+func TestDateTimeLocation_Validate(t *testing.T) {
+	d := shared.DateTimeLocation{Year: 2023, Month: 2, Day: 29, Hour: 12, Latitude: shared.F(35.7), Longitude: shared.F(139.7)}
+	assert.Equal(t, map[string]string{"day": "date"}, violations(t, d.Validate()))
+}
+
+func TestDateTimeLocation_Validate_MonthAndDayRequired(t *testing.T) {
+	d := shared.DateTimeLocation{Year: 2024, City: "London", CountryCode: "GB"}
+	assert.Equal(t, map[string]string{"month": "required", "day": "required"}, violations(t, d.Validate()))
+}
+
+func TestDateTimeLocation_Validate_Coordinates(t *testing.T) {
+	d := shared.DateTimeLocation{Year: 2024, Month: 3, Day: 1, Latitude: shared.F(51.5)}
+	assert.Equal(t, map[string]string{"": "location"}, violations(t, d.Validate()))
+
+	d.Longitude = shared.F(0.0)
+	require.NoError(t, d.Validate())
+}
+
+func TestDateRange_Validate(t *testing.T) {
+	require.NoError(t, shared.DateRange{Start: shared.NewDate(2024, 1, 1), End: shared.NewDate(2024, 1, 1)}.Validate())
+
+	r := shared.DateRange{Start: shared.NewDate(2024, 2, 1), End: shared.NewDate(2024, 1, 1)}
+	assert.Equal(t, map[string]string{"start": "range"}, violations(t, r.Validate()))
+}
+
+func TestSubject_ValidateNested(t *testing.T) {
+	type params struct {
+		Subject shared.Subject `json:"subject" validate:"required"`
+	}
+	b := london()
+	b.Hour, b.Latitude, b.Longitude, b.Day = shared.F(24), shared.F(95.0), shared.F(0.0), shared.F(32)
+	err := validator.Validate(params{Subject: shared.Subject{BirthData: b}})
+	assert.Equal(t, map[string]string{
+		"subject.birth_data.day":      "max",
+		"subject.birth_data.hour":     "max",
+		"subject.birth_data.latitude": "latitude",
+	}, violations(t, err))
+}
+
+func TestBirthData_Validate_ExplicitZero(t *testing.T) {
+	// Midnight on the prime meridian at the equator, with no city.
+	b := shared.BirthData{
+		Year: 1990, Month: shared.F(1), Day: shared.F(1),
+		Hour: shared.F(0), Minute: shared.F(0),
+		Latitude: shared.F(0.0), Longitude: shared.F(0.0),
+	}
+	require.NoError(t, validator.Validate(b))
+
+	// An explicit zero is checked against the rules.
+	b.Month = shared.F(0)
+	assert.Equal(t, map[string]string{"month": "min"}, violations(t, validator.Validate(b)))
+}
+
+func TestBirthData_Validate_TimeUnknown(t *testing.T) {
+	b := london()
+	b.TimeUnknown = true
+	b.Rodden = shared.RoddenX
+	assert.Equal(t, map[string]string{"hour": "time_unknown", "minute": "time_unknown"}, violations(t, b.Validate()))
+
+	b.Hour, b.Minute = shared.Field[int]{}, shared.Field[int]{}
+	require.NoError(t, validator.Validate(b))
+
+	b.Rodden = "Z"
+	assert.Equal(t, map[string]string{"rodden_rating": "oneof"}, violations(t, validator.Validate(b)))
+}
+
+func TestBirthData_MarshalJSON(t *testing.T) {
+	b := shared.BirthData{
+		Year: 1990, Month: shared.F(1), Day: shared.F(1),
+		Hour: shared.F(0), Minute: shared.F(0),
+		Latitude: shared.F(0.0), Longitude: shared.F(-0.5),
+	}
+	data, err := json.Marshal(b)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"year":1990,"month":1,"day":1,"hour":0,"minute":0,"latitude":0,"longitude":-0.5}`, string(data))
+
+	data, err = json.Marshal(shared.BirthData{Year: 1990, TimeUnknown: true, Rodden: shared.RoddenX})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"year":1990,"time_unknown":true,"rodden_rating":"X"}`, string(data))
+}